@@ -10,5 +10,6 @@ require (
 	github.com/onsi/ginkgo v1.16.4
 	github.com/onsi/gomega v1.15.0
 	go.uber.org/zap v1.19.0
+	gopkg.in/yaml.v2 v2.4.0
 	moul.io/http2curl v1.0.0
 )
@@ -21,15 +21,22 @@ type Client struct {
 	core.JenkinsCore
 }
 
-// List get the list of artifacts from a build
+// List get the list of artifacts from a build, the buildID which is less than 1 means the last build
 func (q *Client) List(jobName string, buildID int) (artifacts []Artifact, err error) {
-	path := job.ParseJobPath(jobName)
-	var api string
 	if buildID < 1 {
-		api = fmt.Sprintf("%s/lastBuild/wfapi/artifacts", path)
-	} else {
-		api = fmt.Sprintf("%s/%d/wfapi/artifacts", path, buildID)
+		return q.ListByRef(jobName, job.LastBuild)
+	}
+	return q.ListByRef(jobName, job.BuildNumber(buildID))
+}
+
+// ListByRef get the list of artifacts from a build by a build reference
+func (q *Client) ListByRef(jobName string, ref job.BuildRef) (artifacts []Artifact, err error) {
+	jobClient := job.Client{JenkinsCore: q.JenkinsCore}
+
+	var path string
+	if path, err = jobClient.GetBuildPath(jobName, ref); err == nil {
+		api := fmt.Sprintf("%s/wfapi/artifacts", path)
+		err = q.RequestWithData(http.MethodGet, api, nil, nil, 200, &artifacts)
 	}
-	err = q.RequestWithData(http.MethodGet, api, nil, nil, 200, &artifacts)
 	return
 }
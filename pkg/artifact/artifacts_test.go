@@ -2,6 +2,7 @@ package artifact
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/jenkins-zh/jenkins-client/pkg/job"
 	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -56,5 +57,17 @@ var _ = Describe("artifacts test", func() {
 			Expect(err).To(BeNil())
 			Expect(len(artifacts)).To(Equal(0))
 		})
+
+		It("should success, with the last successful build", func() {
+			artifactClient.UserName = username
+			artifactClient.Token = password
+
+			jobName := "fakename"
+			PrepareGetArtifactsByRef(roundTripper, artifactClient.URL, username, password, jobName, job.LastSuccessfulBuild)
+
+			artifacts, err := artifactClient.ListByRef(jobName, job.LastSuccessfulBuild)
+			Expect(err).To(BeNil())
+			Expect(len(artifacts)).To(Equal(1))
+		})
 	})
 })
@@ -14,13 +14,17 @@ import (
 // PrepareGetArtifacts only for test
 func PrepareGetArtifacts(roundTripper *mhttp.MockRoundTripper, rootURL, user, passwd,
 	jobName string, buildID int) (response *http.Response) {
-	path := job.ParseJobPath(jobName)
-	var api string
-	if buildID <= 0 {
-		api = fmt.Sprintf("%s/lastBuild/wfapi/artifacts", path)
-	} else {
-		api = fmt.Sprintf("%s/%d/wfapi/artifacts", path, buildID)
+	ref := job.BuildNumber(buildID)
+	if buildID < 1 {
+		ref = job.LastBuild
 	}
+	return PrepareGetArtifactsByRef(roundTripper, rootURL, user, passwd, jobName, ref)
+}
+
+// PrepareGetArtifactsByRef only for test
+func PrepareGetArtifactsByRef(roundTripper *mhttp.MockRoundTripper, rootURL, user, passwd,
+	jobName string, ref job.BuildRef) (response *http.Response) {
+	api := fmt.Sprintf("%s/%s/wfapi/artifacts", job.ParseJobPath(jobName), ref)
 	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", rootURL, api), nil)
 	response = &http.Response{
 		StatusCode: 200,
@@ -3,6 +3,9 @@ package casc
 import (
 	"github.com/jenkins-zh/jenkins-client/pkg/core"
 	"net/http"
+	"strings"
+
+	httpdownloader "github.com/linuxsuren/http-downloader/pkg"
 )
 
 // Manager is the client of configuration as code
@@ -55,3 +58,10 @@ func (c *Manager) Apply() (err error) {
 		nil, nil, 200)
 	return
 }
+
+// ApplyConfig applies the given YAML config of configuration-as-code
+func (c *Manager) ApplyConfig(config string) (err error) {
+	_, err = c.RequestWithoutData(http.MethodPost, "/configuration-as-code/apply",
+		map[string]string{httpdownloader.ContentType: "text/yaml"}, strings.NewReader(config), 200)
+	return
+}
@@ -47,6 +47,13 @@ var _ = Describe("", func() {
 		Expect(schema).To(Equal("sample"))
 	})
 
+	It("apply a config", func() {
+		casc.PrepareForSASCApplyConfig(roundTripper, cascManager.URL, "", "", "jenkins: {}")
+
+		err := cascManager.ApplyConfig("jenkins: {}")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
 	Context("with error code", func() {
 		BeforeEach(func() {
 			casc.PrepareForSASCExportWithCode(roundTripper, cascManager.URL, "", "", 500)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/jenkins-zh/jenkins-client/pkg/core"
 	"net/http"
+	"strings"
 
 	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
 )
@@ -22,6 +23,14 @@ func PrepareForSASCApply(roundTripper *mhttp.MockRoundTripper, rootURL, user, pa
 	core.PrepareCommonPost(request, "", roundTripper, user, password, rootURL)
 }
 
+// PrepareForSASCApplyConfig only for test
+func PrepareForSASCApplyConfig(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, config string) {
+	request, _ := http.NewRequest(http.MethodPost,
+		fmt.Sprintf("%s/configuration-as-code/apply", rootURL), strings.NewReader(config))
+	request.Header.Add("Content-Type", "text/yaml")
+	core.PrepareCommonPost(request, "", roundTripper, user, password, rootURL)
+}
+
 // PrepareForSASCExport only for test
 func PrepareForSASCExport(roundTripper *mhttp.MockRoundTripper, rootURL, user, password string) (
 	response *http.Response) {
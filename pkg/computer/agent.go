@@ -0,0 +1,309 @@
+package computer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	httpdownloader "github.com/linuxsuren/http-downloader/pkg"
+)
+
+// AgentMode decides how Jenkins uses an agent
+type AgentMode string
+
+const (
+	// AgentModeNormal means using the agent as much as possible
+	AgentModeNormal AgentMode = "NORMAL"
+	// AgentModeExclusive means only building the jobs with matching label expressions
+	AgentModeExclusive AgentMode = "EXCLUSIVE"
+)
+
+// LauncherType is the type of the way to launch an agent
+type LauncherType string
+
+const (
+	// LauncherJNLP means the agent connects to Jenkins by itself, via TCP or WebSocket
+	LauncherJNLP LauncherType = "hudson.slaves.JNLPLauncher"
+	// LauncherSSH means Jenkins launches the agent via SSH, it requires the ssh-slaves plugin
+	LauncherSSH LauncherType = "hudson.plugins.sshslaves.SSHLauncher"
+	// LauncherCommand means Jenkins launches the agent via a command on the controller
+	LauncherCommand LauncherType = "hudson.slaves.CommandLauncher"
+)
+
+// RetentionType is the strategy of keeping an agent online
+type RetentionType string
+
+const (
+	// RetentionAlways keeps the agent online as much as possible
+	RetentionAlways RetentionType = "hudson.slaves.RetentionStrategy$Always"
+	// RetentionDemand brings the agent online when there's demand, and takes it offline when it's idle
+	RetentionDemand RetentionType = "hudson.slaves.RetentionStrategy$Demand"
+)
+
+// HostKeyVerificationType is the strategy of verifying the host key of an SSH agent
+type HostKeyVerificationType string
+
+const (
+	// HostKeyVerificationKnownHosts verifies the host key by the known_hosts file on the controller
+	HostKeyVerificationKnownHosts HostKeyVerificationType = "hudson.plugins.sshslaves.verifiers.KnownHostsFileKeyVerificationStrategy"
+	// HostKeyVerificationManuallyTrusted trusts the host key of the first connection, then verifies it
+	HostKeyVerificationManuallyTrusted HostKeyVerificationType = "hudson.plugins.sshslaves.verifiers.ManuallyTrustedKeyVerificationStrategy"
+	// HostKeyVerificationNonVerifying doesn't verify the host key, it's vulnerable to the man-in-the-middle attack
+	HostKeyVerificationNonVerifying HostKeyVerificationType = "hudson.plugins.sshslaves.verifiers.NonVerifyingKeyVerificationStrategy"
+)
+
+// AgentSpec is the specification of a permanent agent
+type AgentSpec struct {
+	Name         string
+	Description  string
+	NumExecutors int
+	RemoteFS     string
+	Labels       []string
+	Mode         AgentMode
+	Launcher     Launcher
+	// Env is the environment variables of the agent
+	Env map[string]string
+	// Tools is the tool locations of the agent
+	Tools     []ToolLocation
+	Retention Retention
+}
+
+// Launcher is the way to launch an agent, only the fields of the type are used
+type Launcher struct {
+	Type LauncherType
+
+	// the fields of JNLP launcher
+	WorkDir   string
+	WebSocket bool
+	Tunnel    string
+	VMArgs    string
+
+	// the fields of SSH launcher
+	Host          string
+	Port          int
+	CredentialsID string
+	JavaPath      string
+	JVMOptions    string
+	// HostKeyVerification is the strategy of verifying the host key, the default one is the known_hosts file
+	HostKeyVerification HostKeyVerificationType
+	// RequireInitialManualTrust requires approving the host key of the first connection manually,
+	// it's only used by the manually trusted strategy
+	RequireInitialManualTrust bool
+
+	// the field of command launcher
+	Command string
+}
+
+// ToolLocation is the location of a tool on an agent
+type ToolLocation struct {
+	// Key is the descriptor and name of the tool, e.g. hudson.model.JDK$DescriptorImpl@jdk11
+	Key  string
+	Home string
+}
+
+// Retention is the retention strategy of an agent
+type Retention struct {
+	Type RetentionType
+	// InDemandDelay is the minutes of waiting before bringing the agent online
+	InDemandDelay int
+	// IdleDelay is the minutes of idle before taking the agent offline
+	IdleDelay int
+}
+
+// NewDefaultAgentSpec returns the spec of a JNLP agent with the default settings
+func NewDefaultAgentSpec(name string) AgentSpec {
+	return AgentSpec{
+		Name:         name,
+		NumExecutors: 1,
+		RemoteFS:     GetDefaultAgentWorkDir(),
+		Labels:       strings.Fields(getDefaultAgentLabels()),
+		Mode:         AgentModeNormal,
+		Launcher:     Launcher{Type: LauncherJNLP},
+		Retention:    Retention{Type: RetentionAlways},
+	}
+}
+
+// CreateAgent creates a permanent agent by the spec
+func (c *Client) CreateAgent(spec AgentSpec) (err error) {
+	var payload *strings.Reader
+	if payload, err = GetPayloadForAgent(spec); err != nil {
+		return
+	}
+
+	formData := url.Values{
+		"name": {spec.Name},
+		"mode": {"hudson.slaves.DumbSlave"},
+	}
+	if _, err = c.RequestWithoutData(http.MethodPost, "/computer/createItem",
+		map[string]string{httpdownloader.ContentType: httpdownloader.ApplicationForm},
+		strings.NewReader(formData.Encode()), 200); err == nil {
+		_, err = c.RequestWithoutData(http.MethodPost, "/computer/doCreateItem",
+			map[string]string{httpdownloader.ContentType: httpdownloader.ApplicationForm}, payload, 200)
+	}
+	return
+}
+
+// UpdateAgent updates an agent by the spec, all the settings will be replaced
+func (c *Client) UpdateAgent(spec AgentSpec) (err error) {
+	var (
+		api     string
+		payload *strings.Reader
+	)
+	if api, err = c.getNodeAPI(spec.Name, "/configSubmit"); err != nil {
+		return
+	}
+	if payload, err = GetPayloadForAgent(spec); err == nil {
+		_, err = c.RequestWithoutData(http.MethodPost, api,
+			map[string]string{httpdownloader.ContentType: httpdownloader.ApplicationForm}, payload, 200)
+	}
+	return
+}
+
+// GetConfig returns the config.xml of an agent
+func (c *Client) GetConfig(name string) (config string, err error) {
+	return c.getNodeText(name, "/config.xml")
+}
+
+// UpdateConfig replaces the config.xml of an agent
+func (c *Client) UpdateConfig(name, config string) (err error) {
+	var api string
+	if api, err = c.getNodeAPI(name, "/config.xml"); err == nil {
+		_, err = c.RequestWithoutData(http.MethodPost, api,
+			map[string]string{httpdownloader.ContentType: "application/xml"}, strings.NewReader(config), 200)
+	}
+	return
+}
+
+// GetPayloadForAgent returns a form payload for creating or updating an agent
+func GetPayloadForAgent(spec AgentSpec) (payload *strings.Reader, err error) {
+	var data []byte
+	if data, err = json.MarshalIndent(getAgentForm(spec), "", "\t"); err == nil {
+		formData := url.Values{
+			"name": {spec.Name},
+			"type": {"hudson.slaves.DumbSlave"},
+			"json": {string(data)},
+		}
+		payload = strings.NewReader(formData.Encode())
+	}
+	return
+}
+
+// getAgentForm returns the structured form which is the same as the UI submits
+func getAgentForm(spec AgentSpec) map[string]interface{} {
+	mode := spec.Mode
+	if mode == "" {
+		mode = AgentModeNormal
+	}
+
+	nodeProperties := map[string]interface{}{
+		"stapler-class-bag": "true",
+	}
+	if len(spec.Env) > 0 {
+		env := make([]map[string]string, 0, len(spec.Env))
+		for _, key := range sortedKeys(spec.Env) {
+			env = append(env, map[string]string{"key": key, "value": spec.Env[key]})
+		}
+		nodeProperties["hudson-slaves-EnvironmentVariablesNodeProperty"] = map[string]interface{}{"env": env}
+	}
+	if len(spec.Tools) > 0 {
+		locations := make([]map[string]string, 0, len(spec.Tools))
+		for _, tool := range spec.Tools {
+			locations = append(locations, map[string]string{"key": tool.Key, "home": tool.Home})
+		}
+		nodeProperties["hudson-tools-ToolLocationNodeProperty"] = map[string]interface{}{"locations": locations}
+	}
+
+	return map[string]interface{}{
+		"name":              spec.Name,
+		"nodeDescription":   spec.Description,
+		"numExecutors":      strconv.Itoa(spec.NumExecutors),
+		"remoteFS":          spec.RemoteFS,
+		"labelString":       strings.Join(spec.Labels, " "),
+		"mode":              mode,
+		"launcher":          getLauncherForm(spec.Launcher),
+		"retentionStrategy": getRetentionForm(spec.Retention),
+		"nodeProperties":    nodeProperties,
+		"type":              "hudson.slaves.DumbSlave",
+	}
+}
+
+func getLauncherForm(launcher Launcher) map[string]interface{} {
+	switch launcher.Type {
+	case LauncherSSH:
+		port := launcher.Port
+		if port <= 0 {
+			port = 22
+		}
+		return map[string]interface{}{
+			"stapler-class":                  string(LauncherSSH),
+			"$class":                         string(LauncherSSH),
+			"host":                           launcher.Host,
+			"port":                           strconv.Itoa(port),
+			"credentialsId":                  launcher.CredentialsID,
+			"javaPath":                       launcher.JavaPath,
+			"jvmOptions":                     launcher.JVMOptions,
+			"sshHostKeyVerificationStrategy": getHostKeyVerificationForm(launcher),
+		}
+	case LauncherCommand:
+		return map[string]interface{}{
+			"stapler-class": string(LauncherCommand),
+			"$class":        string(LauncherCommand),
+			"command":       launcher.Command,
+		}
+	default:
+		return map[string]interface{}{
+			"stapler-class": string(LauncherJNLP),
+			"$class":        string(LauncherJNLP),
+			"workDirSettings": map[string]interface{}{
+				"disabled":               false,
+				"workDirPath":            launcher.WorkDir,
+				"internalDir":            "remoting",
+				"failIfWorkDirIsMissing": false,
+			},
+			"webSocket": launcher.WebSocket,
+			"tunnel":    launcher.Tunnel,
+			"vmargs":    launcher.VMArgs,
+		}
+	}
+}
+
+func getHostKeyVerificationForm(launcher Launcher) map[string]interface{} {
+	strategy := launcher.HostKeyVerification
+	if strategy == "" {
+		strategy = HostKeyVerificationKnownHosts
+	}
+	form := map[string]interface{}{
+		"stapler-class": string(strategy),
+		"$class":        string(strategy),
+	}
+	if strategy == HostKeyVerificationManuallyTrusted {
+		form["requireInitialManualTrust"] = launcher.RequireInitialManualTrust
+	}
+	return form
+}
+
+func getRetentionForm(retention Retention) map[string]interface{} {
+	if retention.Type == RetentionDemand {
+		return map[string]interface{}{
+			"stapler-class": string(RetentionDemand),
+			"$class":        string(RetentionDemand),
+			"inDemandDelay": strconv.Itoa(retention.InDemandDelay),
+			"idleDelay":     strconv.Itoa(retention.IdleDelay),
+		}
+	}
+	return map[string]interface{}{
+		"stapler-class": string(RetentionAlways),
+		"$class":        string(RetentionAlways),
+	}
+}
+
+func sortedKeys(data map[string]string) (keys []string) {
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
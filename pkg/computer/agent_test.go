@@ -0,0 +1,106 @@
+package computer_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"testing"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/computer"
+)
+
+func getAgentForm(t *testing.T, spec computer.AgentSpec) (form map[string]interface{}) {
+	payload, err := computer.GetPayloadForAgent(spec)
+	if err != nil {
+		t.Fatalf("GetPayloadForAgent() error = %v", err)
+	}
+	data, _ := ioutil.ReadAll(payload)
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		t.Fatalf("invalid form: %v", err)
+	}
+	if err = json.Unmarshal([]byte(values.Get("json")), &form); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	return
+}
+
+func TestGetPayloadForAgent(t *testing.T) {
+	form := getAgentForm(t, computer.AgentSpec{
+		Name:         "ssh-agent",
+		NumExecutors: 2,
+		RemoteFS:     "/home/jenkins",
+		Labels:       []string{"linux", "docker"},
+		Mode:         computer.AgentModeExclusive,
+		Launcher: computer.Launcher{
+			Type:          computer.LauncherSSH,
+			Host:          "10.0.0.1",
+			CredentialsID: "ssh",
+		},
+		Env:   map[string]string{"B": "2", "A": "1"},
+		Tools: []computer.ToolLocation{{Key: "hudson.model.JDK$DescriptorImpl@jdk11", Home: "/opt/jdk11"}},
+		Retention: computer.Retention{
+			Type:      computer.RetentionDemand,
+			IdleDelay: 10,
+		},
+	})
+
+	if form["numExecutors"] != "2" || form["labelString"] != "linux docker" || form["mode"] != "EXCLUSIVE" {
+		t.Errorf("unexpected form: %v", form)
+	}
+	launcher := form["launcher"].(map[string]interface{})
+	if launcher["$class"] != string(computer.LauncherSSH) || launcher["host"] != "10.0.0.1" || launcher["port"] != "22" {
+		t.Errorf("unexpected launcher: %v", launcher)
+	}
+	verification := launcher["sshHostKeyVerificationStrategy"].(map[string]interface{})
+	if verification["$class"] != string(computer.HostKeyVerificationKnownHosts) {
+		t.Errorf("unexpected host key verification strategy: %v", verification)
+	}
+	retention := form["retentionStrategy"].(map[string]interface{})
+	if retention["$class"] != string(computer.RetentionDemand) || retention["idleDelay"] != "10" {
+		t.Errorf("unexpected retention strategy: %v", retention)
+	}
+	properties := form["nodeProperties"].(map[string]interface{})
+	env := properties["hudson-slaves-EnvironmentVariablesNodeProperty"].(map[string]interface{})["env"].([]interface{})
+	if len(env) != 2 || env[0].(map[string]interface{})["key"] != "A" {
+		t.Errorf("unexpected env: %v", env)
+	}
+	locations := properties["hudson-tools-ToolLocationNodeProperty"].(map[string]interface{})["locations"].([]interface{})
+	if len(locations) != 1 || locations[0].(map[string]interface{})["home"] != "/opt/jdk11" {
+		t.Errorf("unexpected tool locations: %v", locations)
+	}
+}
+
+func TestGetPayloadForAgent_HostKeyVerification(t *testing.T) {
+	form := getAgentForm(t, computer.AgentSpec{
+		Name: "ssh-agent",
+		Launcher: computer.Launcher{
+			Type:                      computer.LauncherSSH,
+			Host:                      "10.0.0.1",
+			HostKeyVerification:       computer.HostKeyVerificationManuallyTrusted,
+			RequireInitialManualTrust: true,
+		},
+	})
+
+	launcher := form["launcher"].(map[string]interface{})
+	verification := launcher["sshHostKeyVerificationStrategy"].(map[string]interface{})
+	if verification["$class"] != string(computer.HostKeyVerificationManuallyTrusted) ||
+		verification["requireInitialManualTrust"] != true {
+		t.Errorf("unexpected host key verification strategy: %v", verification)
+	}
+}
+
+func TestGetPayloadForCreateAgent(t *testing.T) {
+	data, _ := ioutil.ReadAll(computer.GetPayloadForCreateAgent("fake"))
+	values, _ := url.ParseQuery(string(data))
+	form := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(values.Get("json")), &form); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if form["remoteFS"] != computer.GetDefaultAgentWorkDir() || form["numExecutors"] != "1" {
+		t.Errorf("unexpected form: %v", form)
+	}
+	if launcher := form["launcher"].(map[string]interface{}); launcher["$class"] != string(computer.LauncherJNLP) {
+		t.Errorf("unexpected launcher: %v", launcher)
+	}
+}
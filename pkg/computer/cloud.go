@@ -0,0 +1,264 @@
+package computer
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/casc"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// CloudKubernetes is the type of the clouds from the kubernetes plugin
+	CloudKubernetes = "kubernetes"
+	// CloudDocker is the type of the clouds from the docker-plugin
+	CloudDocker = "docker"
+	// CloudEC2 is the type of the clouds from the ec2 plugin
+	CloudEC2 = "amazonEC2"
+)
+
+// cloudComputerClasses are the computer classes of the known clouds
+var cloudComputerClasses = map[string]string{
+	"org.csanchez.jenkins.plugins.kubernetes.KubernetesComputer": CloudKubernetes,
+	"io.jenkins.docker.DockerComputer":                           CloudDocker,
+	"hudson.plugins.ec2.EC2Computer":                             CloudEC2,
+}
+
+// Cloud is a cloud which provisions agents on demand
+type Cloud struct {
+	Name string
+	// Type is the key of the cloud in configuration-as-code, e.g. kubernetes, docker
+	Type      string
+	Templates []CloudTemplate
+}
+
+// CloudTemplate is the template of the agents of a cloud
+type CloudTemplate struct {
+	Name   string
+	Labels []string
+	// Image is the container image or the machine image of the agents
+	Image string
+}
+
+// CloudAgent is an agent which is provisioned by a cloud
+type CloudAgent struct {
+	Computer Computer
+	// CloudType is the type of the cloud, see also Cloud.Type
+	CloudType string
+	// Cloud and Template are empty if the cloud config is not available or there's no matched one
+	Cloud    string
+	Template string
+}
+
+// IsCloudAgent returns true if the computer is provisioned by a known cloud
+func (c *Computer) IsCloudAgent() bool {
+	_, ok := cloudComputerClasses[c.Class]
+	return ok
+}
+
+// GetClouds returns the configured clouds, it requires the configuration-as-code plugin
+func (c *Client) GetClouds() (clouds []Cloud, err error) {
+	cascClient := &casc.Manager{JenkinsCore: c.JenkinsCore}
+	var config string
+	if config, err = cascClient.Export(); err == nil {
+		clouds, err = ParseClouds(config)
+	}
+	return
+}
+
+// ParseClouds parses the clouds from the YAML of configuration-as-code
+func ParseClouds(config string) (clouds []Cloud, err error) {
+	cascConfig := struct {
+		Jenkins struct {
+			Clouds []map[string]map[interface{}]interface{} `yaml:"clouds"`
+		} `yaml:"jenkins"`
+	}{}
+	if err = yaml.Unmarshal([]byte(config), &cascConfig); err != nil {
+		err = fmt.Errorf("invalid config of configuration-as-code, error: %v", err)
+		return
+	}
+
+	for _, item := range cascConfig.Jenkins.Clouds {
+		for cloudType, fields := range item {
+			cloud := Cloud{
+				Name: stringField(fields, "name"),
+				Type: cloudType,
+			}
+			for _, template := range mapsField(fields, "templates") {
+				cloud.Templates = append(cloud.Templates, parseCloudTemplate(template))
+			}
+			clouds = append(clouds, cloud)
+		}
+	}
+	return
+}
+
+// Provision asks a cloud to provision an agent by the template name.
+// It requires the cloud plugin to support the provision action, e.g. the ec2 plugin.
+// The clouds of kubernetes and docker don't support it, they provision agents for the queue items only
+func (c *Client) Provision(cloud, template string) (err error) {
+	// the cloud config is optional, the type is checked only if it's available
+	clouds, _ := c.GetClouds()
+	for _, item := range clouds {
+		if item.Name == cloud {
+			return c.provision(item, template)
+		}
+	}
+	return c.provision(Cloud{Name: cloud}, template)
+}
+
+// ProvisionForLabel provisions an agent by the first template which has the label, and its cloud supports
+// provisioning. The clouds of kubernetes and docker are skipped, they provision agents only when there are
+// queued builds of the label, so it's enough to trigger a build for them
+func (c *Client) ProvisionForLabel(label string) (err error) {
+	var clouds []Cloud
+	if clouds, err = c.GetClouds(); err != nil {
+		return
+	}
+
+	err = fmt.Errorf("no cloud template has the label %s", label)
+	for _, cloud := range clouds {
+		for _, template := range cloud.Templates {
+			if !containsString(template.Labels, label) {
+				continue
+			}
+			if !canProvision(cloud.Type) {
+				err = unsupportedProvisionError(cloud.Type)
+				continue
+			}
+			return c.provision(cloud, template.Name)
+		}
+	}
+	return
+}
+
+func (c *Client) provision(cloud Cloud, template string) (err error) {
+	if !canProvision(cloud.Type) {
+		return unsupportedProvisionError(cloud.Type)
+	}
+
+	api := fmt.Sprintf("/cloud/%s/provision?template=%s", url.PathEscape(cloud.Name), url.QueryEscape(template))
+	_, err = c.RequestWithoutData(http.MethodPost, api, nil, nil, 200)
+	return
+}
+
+// canProvision returns false if the cloud doesn't support the provision action
+func canProvision(cloudType string) bool {
+	return cloudType != CloudKubernetes && cloudType != CloudDocker
+}
+
+func unsupportedProvisionError(cloudType string) error {
+	return fmt.Errorf("provisioning is not supported for cloud type %s", cloudType)
+}
+
+// GetCloudAgents returns the agents provisioned by the known clouds.
+// The template of an agent is found from the cloud config if it's available
+func (c *Client) GetCloudAgents() (agents []CloudAgent, err error) {
+	var computers List
+	if computers, err = c.List(); err != nil {
+		return
+	}
+	// the template of origin is optional, the agents are still listed without the cloud config
+	clouds, _ := c.GetClouds()
+
+	for _, computer := range computers.Computer {
+		if !computer.IsCloudAgent() {
+			continue
+		}
+		agent := CloudAgent{
+			Computer:  computer,
+			CloudType: cloudComputerClasses[computer.Class],
+		}
+		agent.Cloud, agent.Template = findCloudTemplate(clouds, agent.CloudType, &computer)
+		agents = append(agents, agent)
+	}
+	return
+}
+
+// findCloudTemplate finds the template by the name prefix of the agent, then by the labels
+func findCloudTemplate(clouds []Cloud, cloudType string, computer *Computer) (cloud, template string) {
+	var labels []string
+	for _, label := range computer.AssignedLabels {
+		labels = append(labels, label.Name)
+	}
+
+	for _, item := range clouds {
+		if item.Type != cloudType {
+			continue
+		}
+		for _, tpl := range item.Templates {
+			if tpl.Name != "" && strings.HasPrefix(computer.DisplayName, tpl.Name+"-") {
+				return item.Name, tpl.Name
+			}
+		}
+	}
+	for _, item := range clouds {
+		if item.Type != cloudType {
+			continue
+		}
+		for _, tpl := range item.Templates {
+			if len(tpl.Labels) > 0 && containsAll(labels, tpl.Labels) {
+				return item.Name, tpl.Name
+			}
+		}
+	}
+	return
+}
+
+func parseCloudTemplate(fields map[interface{}]interface{}) (template CloudTemplate) {
+	template.Name = stringField(fields, "name")
+	if template.Name == "" {
+		// the templates of ec2 are identified by the description
+		template.Name = stringField(fields, "description")
+	}
+	if labels := stringField(fields, "label"); labels != "" {
+		template.Labels = strings.Fields(labels)
+	} else {
+		template.Labels = strings.Fields(stringField(fields, "labelString"))
+	}
+
+	// the image is in different places of the clouds
+	template.Image = stringField(fields, "image")
+	if template.Image == "" {
+		if base, ok := fields["dockerTemplateBase"].(map[interface{}]interface{}); ok {
+			template.Image = stringField(base, "image")
+		}
+	}
+	if template.Image == "" {
+		if containers := mapsField(fields, "containers"); len(containers) > 0 {
+			template.Image = stringField(containers[0], "image")
+		}
+	}
+	if template.Image == "" {
+		template.Image = stringField(fields, "ami")
+	}
+	return
+}
+
+func stringField(fields map[interface{}]interface{}, key string) string {
+	if value, ok := fields[key]; ok && value != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return ""
+}
+
+func mapsField(fields map[interface{}]interface{}, key string) (maps []map[interface{}]interface{}) {
+	items, _ := fields[key].([]interface{})
+	for _, item := range items {
+		if itemMap, ok := item.(map[interface{}]interface{}); ok {
+			maps = append(maps, itemMap)
+		}
+	}
+	return
+}
+
+func containsAll(items, targets []string) bool {
+	for _, target := range targets {
+		if !containsString(items, target) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package computer_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/computer"
+)
+
+const cloudConfig = `jenkins:
+  systemMessage: "fake"
+  clouds:
+  - kubernetes:
+      name: "kubernetes"
+      serverUrl: "https://kubernetes.default"
+      templates:
+      - name: "maven"
+        label: "maven java"
+        containers:
+        - name: "maven"
+          image: "maven:3-jdk-11"
+  - docker:
+      name: "docker"
+      templates:
+      - labelString: "docker-agent"
+        name: "docker"
+        dockerTemplateBase:
+          image: "jenkins/agent"
+  - amazonEC2:
+      name: "ec2"
+      templates:
+      - description: "linux"
+        labelString: "linux ec2"
+        ami: "ami-123"
+`
+
+func TestParseClouds(t *testing.T) {
+	clouds, err := computer.ParseClouds(cloudConfig)
+	if err != nil {
+		t.Fatalf("ParseClouds() error = %v", err)
+	}
+
+	expected := []computer.Cloud{{
+		Name: "kubernetes",
+		Type: computer.CloudKubernetes,
+		Templates: []computer.CloudTemplate{
+			{Name: "maven", Labels: []string{"maven", "java"}, Image: "maven:3-jdk-11"},
+		},
+	}, {
+		Name: "docker",
+		Type: computer.CloudDocker,
+		Templates: []computer.CloudTemplate{
+			{Name: "docker", Labels: []string{"docker-agent"}, Image: "jenkins/agent"},
+		},
+	}, {
+		Name: "ec2",
+		Type: computer.CloudEC2,
+		Templates: []computer.CloudTemplate{
+			{Name: "linux", Labels: []string{"linux", "ec2"}, Image: "ami-123"},
+		},
+	}}
+	if !reflect.DeepEqual(clouds, expected) {
+		t.Errorf("ParseClouds() = %+v, want %+v", clouds, expected)
+	}
+}
+
+func TestParseClouds_WithoutClouds(t *testing.T) {
+	clouds, err := computer.ParseClouds("jenkins:\n  numExecutors: 2\n")
+	if err != nil || len(clouds) != 0 {
+		t.Errorf("ParseClouds() = %v, %v, want no clouds", clouds, err)
+	}
+
+	if _, err = computer.ParseClouds("jenkins: ["); err == nil {
+		t.Error("ParseClouds() should fail with an invalid YAML")
+	}
+}
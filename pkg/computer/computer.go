@@ -4,13 +4,12 @@ import (
 	"encoding/xml"
 	"fmt"
 	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/job"
+	"github.com/jenkins-zh/jenkins-client/pkg/util"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 	"runtime"
 	"strings"
-
-	httpdownloader "github.com/linuxsuren/http-downloader/pkg"
 )
 
 // Client is client for operate computers
@@ -27,15 +26,19 @@ func (c *Client) List() (computers List, err error) {
 
 // Launch starts up a agent
 func (c *Client) Launch(name string) (err error) {
-	api := fmt.Sprintf("/computer/%s/launchSlaveAgent", name)
-	_, err = c.RequestWithoutData(http.MethodPost, api, nil, nil, 200)
+	var api string
+	if api, err = c.getNodeAPI(name, "/launchSlaveAgent"); err == nil {
+		_, err = c.RequestWithoutData(http.MethodPost, api, nil, nil, 200)
+	}
 	return
 }
 
 // Delete removes a agent from Jenkins
 func (c *Client) Delete(name string) (err error) {
-	api := fmt.Sprintf("/computer/%s/doDelete", name)
-	_, err = c.RequestWithoutData(http.MethodPost, api, nil, nil, 200)
+	var api string
+	if api, err = c.getNodeAPI(name, "/doDelete"); err == nil {
+		_, err = c.RequestWithoutData(http.MethodPost, api, nil, nil, 200)
+	}
 	return
 }
 
@@ -46,8 +49,13 @@ type agentJNLP struct {
 
 // GetSecret returns the secret of an agent
 func (c *Client) GetSecret(name string) (secret string, err error) {
-	api := fmt.Sprintf("/computer/%s/slave-agent.jnlp", name)
-	var response *http.Response
+	var (
+		api      string
+		response *http.Response
+	)
+	if api, err = c.getNodeAPI(name, "/slave-agent.jnlp"); err != nil {
+		return
+	}
 	if response, err = c.RequestWithResponse(http.MethodGet, api, nil, nil); err == nil {
 		if response.StatusCode == http.StatusOK {
 			var data []byte
@@ -66,39 +74,18 @@ func (c *Client) GetSecret(name string) (secret string, err error) {
 	return
 }
 
-// GetLog fetch the log a computer
+// GetLog fetch the log a computer, see also GetLogFrom
 func (c *Client) GetLog(name string) (log string, err error) {
-	var response *http.Response
-	api := fmt.Sprintf("/computer/%s/logText/progressiveText", name)
-	if response, err = c.RequestWithResponse(http.MethodGet, api, nil, nil); err == nil {
-		statusCode := response.StatusCode
-		if statusCode != 200 {
-			err = fmt.Errorf("unexpected status code %d", statusCode)
-			return
-		}
-
-		var data []byte
-		if data, err = ioutil.ReadAll(response.Body); err == nil {
-			log = string(data)
-		}
+	var result job.Log
+	if result, err = c.GetLogFrom(name, 0); err == nil {
+		log = result.Text
 	}
 	return
 }
 
-// Create creates a computer by name
+// Create creates a JNLP computer by name with the default settings
 func (c *Client) Create(name string) (err error) {
-	formData := url.Values{
-		"name": {name},
-		"mode": {"hudson.slaves.DumbSlave"},
-	}
-	payload := strings.NewReader(formData.Encode())
-	if _, err = c.RequestWithoutData(http.MethodPost, "/computer/createItem",
-		map[string]string{httpdownloader.ContentType: httpdownloader.ApplicationForm}, payload, 200); err == nil {
-		payload = GetPayloadForCreateAgent(name)
-		_, err = c.RequestWithoutData(http.MethodPost, "/computer/doCreateItem",
-			map[string]string{httpdownloader.ContentType: httpdownloader.ApplicationForm}, payload, 200)
-	}
-	return
+	return c.CreateAgent(NewDefaultAgentSpec(name))
 }
 
 func getDefaultAgentLabels() string {
@@ -111,38 +98,16 @@ func GetDefaultAgentWorkDir() string {
 	return "/var/tmp/jenkins"
 }
 
-// GetPayloadForCreateAgent returns a payload for creating an agent
+// GetPayloadForCreateAgent returns a payload for creating an agent with the default settings
 func GetPayloadForCreateAgent(name string) *strings.Reader {
-	palyLoad := fmt.Sprintf(`{
-	"name": "%s",
-	"nodeDescription": "",
-	"numExecutors": "1",
-	"remoteFS": "%s",
-	"labelString": "%s",
-	"mode": "NORMAL",
-	"launcher": {
-		"$class": "hudson.slaves.JNLPLauncher",
-		"workDirSettings": {
-			"disabled": false,
-			"workDirPath": "",
-			"internalDir": "remoting",
-			"failIfWorkDirIsMissing": false
-		},
-		"tunnel": "",
-		"vmargs": ""
-	},
-	"type": "hudson.slaves.DumbSlave"
-}`, name, GetDefaultAgentWorkDir(), getDefaultAgentLabels())
-	formData := url.Values{
-		"name": {name},
-		"type": {"hudson.slaves.DumbSlave"},
-		"json": {palyLoad},
-	}
-	return strings.NewReader(formData.Encode())
+	// the form of the default spec is always valid
+	payload, _ := GetPayloadForAgent(NewDefaultAgentSpec(name))
+	return payload
 }
 
 // Computer is the agent of Jenkins
 type Computer struct {
+	Class               string `json:"_class"`
 	AssignedLabels      []Label
 	Description         string
 	DisplayName         string
@@ -155,11 +120,14 @@ type Computer struct {
 	OfflineCause        OfflineCause
 	OfflineCauseReason  string
 	TemporarilyOffline  bool
+	Executors           []Executor
+	OneOffExecutors     []Executor
+	MonitorData         MonitorData
 }
 
 // OfflineCause is the cause of computer offline
 type OfflineCause struct {
-	Timestamp   int64
+	Timestamp   util.Time
 	Description string
 }
 
@@ -171,7 +139,13 @@ type List struct {
 	TotalExecutors int
 }
 
-// Label represents the label of a computer
+// Label represents the label of a computer, only the name is available in the assigned labels
 type Label struct {
-	Name string
+	Name           string
+	Description    string
+	BusyExecutors  int
+	IdleExecutors  int
+	TotalExecutors int
+	Offline        bool
+	Nodes          []LabelNode
 }
@@ -1,8 +1,20 @@
 package computer_test
 
 import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
 	"github.com/golang/mock/gomock"
+	"github.com/jenkins-zh/jenkins-client/pkg/casc"
 	"github.com/jenkins-zh/jenkins-client/pkg/computer"
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
 	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -61,6 +73,58 @@ var _ = Describe("computer test", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("Get the log from an offset", func() {
+		computer.PrepareForComputerProgressiveLogRequest(roundTripper, computerClient.URL, "", "", name, 10, "more", true)
+
+		log, err := computerClient.GetLogFrom(name, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(log.Text).To(Equal("more"))
+		Expect(log.HasMore).To(BeTrue())
+		Expect(log.NextStart).To(Equal(int64(14)))
+	})
+
+	It("Get the log of a non-existing agent", func() {
+		computer.PrepareForComputerProgressiveLogRequest(roundTripper, computerClient.URL, "", "", name, 0, "", false).
+			StatusCode = http.StatusNotFound
+
+		_, err := computerClient.GetLogFrom(name, 0)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Follow the log", func() {
+		computer.PrepareForComputerProgressiveLogRequest(roundTripper, computerClient.URL, "", "", name, 0, "connecting\n", true)
+		computer.PrepareForComputerProgressiveLogRequest(roundTripper, computerClient.URL, "", "", name, 11, "connected\n", false)
+
+		buf := &bytes.Buffer{}
+		err := computerClient.FollowLog(context.Background(), name, time.Millisecond, buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(Equal("connecting\nconnected\n"))
+	})
+
+	It("Follow the log until the context is done", func() {
+		computer.PrepareForComputerProgressiveLogRequest(roundTripper, computerClient.URL, "", "", name, 0, "connected", true)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		buf := &bytes.Buffer{}
+		err := computerClient.FollowLog(ctx, name, time.Minute, buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(Equal("connected"))
+	})
+
+	It("Get the system info and thread dump", func() {
+		computer.PrepareForComputerPageRequest(roundTripper, computerClient.URL, "", "", name, "systemInfo", "fake-info")
+		computer.PrepareForComputerPageRequest(roundTripper, computerClient.URL, "", "", name, "threadDump", "fake-dump")
+
+		info, err := computerClient.GetSystemInfo(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info).To(Equal("fake-info"))
+
+		dump, err := computerClient.GetThreadDump(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dump).To(Equal("fake-dump"))
+	})
+
 	It("Delete an agent", func() {
 		computer.PrepareForComputerDeleteRequest(roundTripper, computerClient.URL, "", "", name)
 
@@ -84,4 +148,435 @@ var _ = Describe("computer test", func() {
 		err := computerClient.Create(name)
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("Update an agent", func() {
+		spec := computer.NewDefaultAgentSpec(name)
+		spec.Labels = []string{"linux"}
+		computer.PrepareForComputerUpdateRequest(roundTripper, computerClient.URL, "", "", spec)
+
+		err := computerClient.UpdateAgent(spec)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Get and update the config of an agent", func() {
+		config := "<slave><name>fake-name</name></slave>"
+		computer.PrepareForComputerGetConfigRequest(roundTripper, computerClient.URL, "", "", name, config)
+		computer.PrepareForComputerUpdateConfigRequest(roundTripper, computerClient.URL, "", "", name, config)
+
+		result, err := computerClient.GetConfig(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(config))
+
+		err = computerClient.UpdateConfig(name, result)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Get an agent", func() {
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", name,
+			`{"displayName":"fake-name","idle":true,"offlineCause":{"timestamp":1629738184144,"description":"maintenance"}}`)
+
+		result, err := computerClient.Get(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Idle).To(BeTrue())
+		Expect(result.OfflineCause.Description).To(Equal("maintenance"))
+		Expect(result.OfflineCause.Timestamp.Millis()).To(Equal(int64(1629738184144)))
+	})
+
+	It("Disconnect an agent", func() {
+		computer.PrepareForComputerOfflineRequest(roundTripper, computerClient.URL, "", "", name, "doDisconnect", "upgrade")
+
+		err := computerClient.Disconnect(name, "upgrade")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Set an agent offline", func() {
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", name, `{"temporarilyOffline":false}`)
+		computer.PrepareForComputerOfflineRequest(roundTripper, computerClient.URL, "", "", name, "toggleOffline", "upgrade")
+
+		err := computerClient.SetOffline(name, "upgrade")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Change the offline cause of an agent", func() {
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", name, `{"temporarilyOffline":true}`)
+		computer.PrepareForComputerOfflineRequest(roundTripper, computerClient.URL, "", "", name, "changeOfflineCause", "upgrade")
+
+		err := computerClient.SetOffline(name, "upgrade")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Set an online agent online", func() {
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", name, `{"temporarilyOffline":false}`)
+
+		err := computerClient.SetOnline(name)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Drain an agent", func() {
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", name, `{"temporarilyOffline":false}`)
+		computer.PrepareForComputerOfflineRequest(roundTripper, computerClient.URL, "", "", name, "toggleOffline", "upgrade")
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", name, `{"temporarilyOffline":true,"idle":false}`)
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", name, `{"temporarilyOffline":true,"idle":true}`)
+
+		err := computerClient.Drain(name, "upgrade", time.Millisecond, 0)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Drain an agent with timeout", func() {
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", name, `{"temporarilyOffline":true}`)
+		computer.PrepareForComputerOfflineRequest(roundTripper, computerClient.URL, "", "", name, "changeOfflineCause", "upgrade")
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", name, `{"temporarilyOffline":true,"idle":false}`)
+
+		err := computerClient.Drain(name, "upgrade", time.Second, time.Millisecond)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Get the executors of an agent", func() {
+		computer.PrepareForComputerExecutorsRequest(roundTripper, computerClient.URL, "", "", name, `{
+  "executors": [
+    {"number": 0, "idle": false, "progress": 40, "currentExecutable": {"number": 3, "url": "http://localhost/job/fake/3/"}},
+    {"number": 1, "idle": true, "progress": -1, "idleStartMilliseconds": 1629738184144}
+  ],
+  "oneOffExecutors": []
+}`)
+
+		executors, oneOffExecutors, err := computerClient.GetExecutors(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(executors).To(HaveLen(2))
+		Expect(executors[0].CurrentExecutable.Number).To(Equal(3))
+		Expect(executors[0].IdleDuration(time.Now())).To(BeZero())
+		Expect(executors[1].IdleDuration(time.Now())).To(BeNumerically(">", time.Hour))
+		Expect(oneOffExecutors).To(BeEmpty())
+	})
+
+	It("Get the running builds", func() {
+		computer.PrepareForComputerExecutorsRequest(roundTripper, computerClient.URL, "", "", "", `{"computer": [{
+  "displayName": "Built-In Node",
+  "executors": [{"number": 0, "idle": true}],
+  "oneOffExecutors": [{"number": -1, "currentExecutable": {"fullDisplayName": "pipeline #2", "number": 2}}]
+}, {
+  "displayName": "agent",
+  "executors": [{"number": 1, "likelyStuck": true, "progress": 99, "currentExecutable": {"fullDisplayName": "freestyle #5", "number": 5}}],
+  "oneOffExecutors": []
+}]}`)
+
+		builds, err := computerClient.GetRunningBuilds()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(builds).To(HaveLen(2))
+		Expect(builds[0].Node).To(Equal("Built-In Node"))
+		Expect(builds[0].OneOff).To(BeTrue())
+		Expect(builds[1].Node).To(Equal("agent"))
+		Expect(builds[1].LikelyStuck).To(BeTrue())
+		Expect(builds[1].Build.FullDisplayName).To(Equal("freestyle #5"))
+	})
+
+	It("Check the health of agents", func() {
+		computer.PrepareForComputerListRequest(roundTripper, computerClient.URL, "", "")
+
+		healths, err := computerClient.CheckHealth(computer.HealthThresholds{MinDiskSpace: 64 * 1024 * 1024 * 1024})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(healths).To(HaveLen(2))
+		Expect(healths[0].Offline).To(BeTrue())
+		Expect(healths[0].IsHealthy()).To(BeTrue())
+		Expect(healths[1].Node).To(Equal("master"))
+		Expect(healths[1].Issues).To(HaveLen(1))
+		Expect(healths[1].Issues[0].Message).To(Equal("the free space of /var/jenkins_home is 29.6 GiB"))
+	})
+
+	It("Get a label", func() {
+		computer.PrepareForLabelRequest(roundTripper, computerClient.URL, "", "", "linux && docker", `{
+  "name": "linux&&docker", "busyExecutors": 1, "idleExecutors": 2, "totalExecutors": 3,
+  "nodes": [{"_class": "hudson.slaves.DumbSlave", "nodeName": "agent"}]
+}`)
+
+		label, err := computerClient.GetLabel("linux && docker")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(label.BusyExecutors).To(Equal(1))
+		Expect(label.Nodes).To(HaveLen(1))
+		Expect(label.Nodes[0].NodeName).To(Equal("agent"))
+	})
+
+	It("Get the load statistics of a label", func() {
+		computer.PrepareForLabelLoadRequest(roundTripper, computerClient.URL, "", "", "linux", `{
+  "queueLength": {"sec10": {"latest": 2, "history": [2, 1.5]}, "min": {"latest": 1.2}, "hour": {"latest": 0.1}},
+  "busyExecutors": {"sec10": {"latest": 1}}
+}`)
+
+		load, err := computerClient.GetLabelLoad("linux")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(load.QueueLength.Sec10.Latest).To(Equal(2.0))
+		Expect(load.QueueLength.Sec10.History).To(HaveLen(2))
+		Expect(load.QueueLength.Min.Latest).To(Equal(1.2))
+		Expect(load.BusyExecutors.Sec10.Latest).To(Equal(1.0))
+	})
+
+	It("Get the queue items of a label", func() {
+		core.PrepareGetQueue(roundTripper, computerClient.URL, "", "")
+
+		items, err := computerClient.GetLabelQueue("linux")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(items).To(BeEmpty())
+	})
+
+	It("Add labels to an agent", func() {
+		computer.PrepareForComputerGetConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<slave>\n  <name>fake-name</name>\n  <label>linux</label>\n</slave>")
+		computer.PrepareForComputerUpdateConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<slave>\n  <name>fake-name</name>\n  <label>linux docker &amp; java</label>\n</slave>")
+
+		err := computerClient.AddLabels(name, "linux", "docker", "&", "java")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Add labels to an agent without labels", func() {
+		computer.PrepareForComputerGetConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<slave>\n  <name>fake-name</name>\n</slave>")
+		computer.PrepareForComputerUpdateConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<slave>\n  <name>fake-name</name>\n  <label>docker</label>\n</slave>")
+
+		err := computerClient.AddLabels(name, "docker")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Add labels to an agent which has nested label elements", func() {
+		installer := "<nodeProperties><hudson.tools.CommandInstaller><label>windows</label></hudson.tools.CommandInstaller></nodeProperties>"
+		computer.PrepareForComputerGetConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<?xml version='1.1' encoding='UTF-8'?>\n<slave>\n  "+installer+"\n  <label>linux</label>\n</slave>")
+		computer.PrepareForComputerUpdateConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<?xml version='1.1' encoding='UTF-8'?>\n<slave>\n  "+installer+"\n  <label>linux docker</label>\n</slave>")
+
+		err := computerClient.AddLabels(name, "docker")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Add labels to an agent which only has nested label elements", func() {
+		installer := "<nodeProperties><hudson.tools.CommandInstaller><label>windows</label></hudson.tools.CommandInstaller></nodeProperties>"
+		computer.PrepareForComputerGetConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<slave>\n  "+installer+"\n</slave>")
+		computer.PrepareForComputerUpdateConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<slave>\n  "+installer+"\n  <label>docker</label>\n</slave>")
+
+		err := computerClient.AddLabels(name, "docker")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Remove labels from an agent", func() {
+		computer.PrepareForComputerGetConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<slave><label>linux docker</label></slave>")
+		computer.PrepareForComputerUpdateConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<slave><label>linux</label></slave>")
+
+		err := computerClient.RemoveLabels(name, "docker", "windows")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Remove a non-existing label from an agent", func() {
+		computer.PrepareForComputerGetConfigRequest(roundTripper, computerClient.URL, "", "", name,
+			"<slave><label/></slave>")
+
+		err := computerClient.RemoveLabels(name, "docker")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Get the command of an inbound agent", func() {
+		command, args := computerClient.GetAgentCommand(computer.InboundAgent{
+			Name:        name,
+			WorkDir:     "/home/jenkins",
+			WebSocket:   true,
+			JavaOptions: []string{"-Xmx512m"},
+		}, "fake-secret")
+		Expect(command).To(Equal("java"))
+		Expect(args).To(Equal([]string{"-Xmx512m", "-jar", "/home/jenkins/agent.jar",
+			"-url", "http://localhost", "-secret", "fake-secret", "-name", name,
+			"-workDir", "/home/jenkins", "-webSocket"}))
+	})
+
+	It("Start an inbound agent", func() {
+		workDir, err := ioutil.TempDir("", "agent")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			_ = os.RemoveAll(workDir)
+		}()
+
+		computer.PrepareForComputerAgent(roundTripper, computerClient.URL, "", "", name, "fake-secret")
+		computer.PrepareForDownloadAgentJarRequest(roundTripper, computerClient.URL, "", "", "fake-jar")
+
+		var command []string
+		stdout := &bytes.Buffer{}
+		cmd, err := computerClient.StartAgent(computer.InboundAgent{
+			Name:    name,
+			WorkDir: workDir,
+			ExecContext: func(name string, arg ...string) *exec.Cmd {
+				command = append([]string{name}, arg...)
+				return exec.Command("go", "version")
+			},
+			Stdout: stdout,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Wait()).To(Succeed())
+		Expect(stdout.String()).To(ContainSubstring("go version"))
+		Expect(command).NotTo(ContainElement("fake-secret"))
+		Expect(command).To(ContainElement("@" + filepath.Join(workDir, "secret")))
+		Expect(command).To(ContainElement(filepath.Join(workDir, "agent.jar")))
+
+		secret, err := os.Stat(filepath.Join(workDir, "secret"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		data, err := ioutil.ReadFile(filepath.Join(workDir, "agent.jar"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("fake-jar"))
+	})
+
+	It("Provision an agent for a label", func() {
+		response := casc.PrepareForSASCExport(roundTripper, computerClient.URL, "", "")
+		response.Body = ioutil.NopCloser(strings.NewReader(`jenkins:
+  clouds:
+  - amazonEC2:
+      name: "ec2"
+      templates:
+      - description: "linux agent"
+        labelString: "linux"`))
+		computer.PrepareForCloudProvisionRequest(roundTripper, computerClient.URL, "", "", "ec2", "linux agent")
+
+		err := computerClient.ProvisionForLabel("linux")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Provision an agent for a label of kubernetes", func() {
+		response := casc.PrepareForSASCExport(roundTripper, computerClient.URL, "", "")
+		response.Body = ioutil.NopCloser(strings.NewReader(`jenkins:
+  clouds:
+  - kubernetes:
+      name: "k8s"
+      templates:
+      - name: "maven"
+        label: "maven"`))
+
+		err := computerClient.ProvisionForLabel("maven")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("provisioning is not supported for cloud type kubernetes"))
+	})
+
+	It("Provision an agent for a label of kubernetes and ec2", func() {
+		response := casc.PrepareForSASCExport(roundTripper, computerClient.URL, "", "")
+		response.Body = ioutil.NopCloser(strings.NewReader(`jenkins:
+  clouds:
+  - kubernetes:
+      name: "k8s"
+      templates:
+      - name: "linux"
+        label: "linux"
+  - amazonEC2:
+      name: "ec2"
+      templates:
+      - description: "linux agent"
+        labelString: "linux"`))
+		computer.PrepareForCloudProvisionRequest(roundTripper, computerClient.URL, "", "", "ec2", "linux agent")
+
+		err := computerClient.ProvisionForLabel("linux")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Provision an agent by the cloud name", func() {
+		response := casc.PrepareForSASCExport(roundTripper, computerClient.URL, "", "")
+		response.Body = ioutil.NopCloser(strings.NewReader(`jenkins:
+  clouds:
+  - docker:
+      name: "docker"`))
+
+		err := computerClient.Provision("docker", "maven")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Provision an agent for an unknown label", func() {
+		casc.PrepareForSASCExport(roundTripper, computerClient.URL, "", "").
+			Body = ioutil.NopCloser(strings.NewReader("jenkins:\n  clouds: []"))
+
+		err := computerClient.ProvisionForLabel("windows")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Get the cloud agents", func() {
+		computer.PrepareForComputerListRequestWithData(roundTripper, computerClient.URL, "", "", `{"computer": [
+  {"_class": "hudson.model.Hudson$MasterComputer", "displayName": "master"},
+  {"_class": "org.csanchez.jenkins.plugins.kubernetes.KubernetesComputer", "displayName": "maven-x1b2c",
+    "assignedLabels": [{"name": "maven"}, {"name": "maven-x1b2c"}]},
+  {"_class": "io.jenkins.docker.DockerComputer", "displayName": "docker-0001",
+    "assignedLabels": [{"name": "docker-agent"}]},
+  {"_class": "io.jenkins.docker.DockerComputer", "displayName": "unknown-0001"}
+]}`)
+		casc.PrepareForSASCExport(roundTripper, computerClient.URL, "", "").
+			Body = ioutil.NopCloser(strings.NewReader(`jenkins:
+  clouds:
+  - kubernetes:
+      name: "k8s"
+      templates:
+      - name: "maven"
+        label: "maven"
+  - docker:
+      name: "docker-cloud"
+      templates:
+      - name: "agent"
+        labelString: "docker-agent"`))
+
+		agents, err := computerClient.GetCloudAgents()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(agents).To(HaveLen(3))
+		Expect(agents[0].CloudType).To(Equal(computer.CloudKubernetes))
+		Expect(agents[0].Cloud).To(Equal("k8s"))
+		Expect(agents[0].Template).To(Equal("maven"))
+		Expect(agents[1].Cloud).To(Equal("docker-cloud"))
+		Expect(agents[1].Template).To(Equal("agent"))
+		Expect(agents[2].CloudType).To(Equal(computer.CloudDocker))
+		Expect(agents[2].Template).To(BeEmpty())
+	})
+
+	It("Get the path of a node with special characters", func() {
+		path, err := computerClient.GetNodePath(computer.NodeName("my agent/#1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/computer/my%20agent%2F%231"))
+	})
+
+	It("Get a node with special characters", func() {
+		roundTripper.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(request *http.Request) (*http.Response, error) {
+			Expect(request.URL.EscapedPath()).To(Equal("/computer/my%20agent%2F%231/api/json"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    request,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"displayName":"my agent/#1"}`)),
+			}, nil
+		})
+
+		node, err := computerClient.Get("my agent/#1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(node.DisplayName).To(Equal("my agent/#1"))
+	})
+
+	It("Get the built-in node without the version of Jenkins", func() {
+		computer.PrepareForJenkinsVersionRequest(roundTripper, computerClient.URL, "", "", "")
+
+		path, err := computerClient.GetNodePath(computer.BuiltInNode)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/computer/(built-in)"))
+	})
+
+	It("Get the built-in node of a new Jenkins", func() {
+		computer.PrepareForJenkinsVersionRequest(roundTripper, computerClient.URL, "", "", "2.319.1")
+		computer.PrepareForComputerGetRequest(roundTripper, computerClient.URL, "", "", "(built-in)",
+			`{"displayName": "Built-In Node"}`)
+
+		// the legacy name references the built-in node as well
+		node, err := computerClient.Get("(master)")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(node.DisplayName).To(Equal("Built-In Node"))
+	})
+
+	It("Get the built-in node of an old Jenkins", func() {
+		computer.PrepareForJenkinsVersionRequest(roundTripper, computerClient.URL, "", "", "2.289.3")
+
+		path, err := computerClient.GetNodePath(computer.BuiltInNode)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/computer/(master)"))
+	})
 })
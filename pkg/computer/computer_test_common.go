@@ -43,7 +43,7 @@ func PrepareForComputerLogRequest(roundTripper *mhttp.MockRoundTripper, rootURL,
 // PrepareForComputerLogRequestWithCode only for test
 func PrepareForComputerLogRequestWithCode(roundTripper *mhttp.MockRoundTripper, rootURL, user, password,
 	name string, statusCode int) {
-	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/computer/%s/logText/progressiveText", rootURL, name), nil)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/computer/%s/logText/progressiveText?start=0", rootURL, name), nil)
 	response := &http.Response{
 		StatusCode: statusCode,
 		Request:    request,
@@ -190,3 +190,160 @@ func PrepareForComputerList() string {
   "totalExecutors" : 2
 }`
 }
+
+// PrepareForComputerUpdateRequest only for test
+func PrepareForComputerUpdateRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password string, spec AgentSpec) {
+	payload, _ := GetPayloadForAgent(spec)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/computer/%s/configSubmit", rootURL, spec.Name), payload)
+	request.Header.Add(httpdownloader.ContentType, httpdownloader.ApplicationForm)
+	core.PrepareCommonPost(request, "", roundTripper, user, password, rootURL)
+}
+
+// PrepareForComputerGetConfigRequest only for test
+func PrepareForComputerGetConfigRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, name, config string) {
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/computer/%s/config.xml", rootURL, name), nil)
+	response := &http.Response{
+		StatusCode: 200,
+		Request:    request,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(config)),
+	}
+	roundTripper.EXPECT().
+		RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+	if user != "" && password != "" {
+		request.SetBasicAuth(user, password)
+	}
+}
+
+// PrepareForComputerUpdateConfigRequest only for test
+func PrepareForComputerUpdateConfigRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, name, config string) {
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/computer/%s/config.xml", rootURL, name),
+		strings.NewReader(config))
+	request.Header.Add(httpdownloader.ContentType, "application/xml")
+	core.PrepareCommonPost(request, "", roundTripper, user, password, rootURL)
+}
+
+// PrepareForComputerGetRequest only for test
+func PrepareForComputerGetRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, name, body string) {
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/computer/%s/api/json", rootURL, name), nil)
+	response := &http.Response{
+		StatusCode: 200,
+		Request:    request,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+	roundTripper.EXPECT().
+		RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+	if user != "" && password != "" {
+		request.SetBasicAuth(user, password)
+	}
+}
+
+// PrepareForComputerOfflineRequest only for test, the action could be toggleOffline, changeOfflineCause or doDisconnect
+func PrepareForComputerOfflineRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, name, action, message string) {
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/computer/%s/%s?offlineMessage=%s",
+		rootURL, name, action, url.QueryEscape(message)), nil)
+	core.PrepareCommonPost(request, "", roundTripper, user, password, rootURL)
+}
+
+// PrepareForComputerExecutorsRequest only for test, the name is empty for all computers
+func PrepareForComputerExecutorsRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, name, body string) {
+	api := fmt.Sprintf("%s/computer/api/json?tree=computer[displayName,%s]", rootURL, executorsTree)
+	if name != "" {
+		api = fmt.Sprintf("%s/computer/%s/api/json?tree=%s", rootURL, name, executorsTree)
+	}
+	request, _ := http.NewRequest(http.MethodGet, api, nil)
+	response := &http.Response{
+		StatusCode: 200,
+		Request:    request,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+	roundTripper.EXPECT().
+		RoundTrip(core.NewVerboseRequestMatcher(request).WithQuery()).Return(response, nil)
+	if user != "" && password != "" {
+		request.SetBasicAuth(user, password)
+	}
+}
+
+// PrepareForLabelRequest only for test
+func PrepareForLabelRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, expression, body string) {
+	prepareForGet(roundTripper, fmt.Sprintf("%s/label/%s/api/json", rootURL, url.PathEscape(expression)), user, password, body)
+}
+
+// PrepareForLabelLoadRequest only for test
+func PrepareForLabelLoadRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, expression, body string) {
+	prepareForGet(roundTripper, fmt.Sprintf("%s/label/%s/loadStatistics/api/json?depth=1", rootURL, url.PathEscape(expression)),
+		user, password, body)
+}
+
+func prepareForGet(roundTripper *mhttp.MockRoundTripper, api, user, password, body string) {
+	request, _ := http.NewRequest(http.MethodGet, api, nil)
+	response := &http.Response{
+		StatusCode: 200,
+		Request:    request,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+	roundTripper.EXPECT().
+		RoundTrip(core.NewVerboseRequestMatcher(request).WithQuery()).Return(response, nil)
+	if user != "" && password != "" {
+		request.SetBasicAuth(user, password)
+	}
+}
+
+// PrepareForDownloadAgentJarRequest only for test
+func PrepareForDownloadAgentJarRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, content string) {
+	prepareForGet(roundTripper, fmt.Sprintf("%s/jnlpJars/agent.jar", rootURL), user, password, content)
+}
+
+// PrepareForComputerListRequestWithData only for test
+func PrepareForComputerListRequestWithData(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, body string) {
+	prepareForGet(roundTripper, fmt.Sprintf("%s/computer/api/json", rootURL), user, password, body)
+}
+
+// PrepareForCloudProvisionRequest only for test
+func PrepareForCloudProvisionRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, cloud, template string) {
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/cloud/%s/provision?template=%s",
+		rootURL, url.PathEscape(cloud), url.QueryEscape(template)), nil)
+	core.PrepareCommonPost(request, "", roundTripper, user, password, rootURL)
+}
+
+// PrepareForComputerProgressiveLogRequest only for test
+func PrepareForComputerProgressiveLogRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, name string,
+	start int64, text string, hasMore bool) (response *http.Response) {
+	request, _ := http.NewRequest(http.MethodGet,
+		fmt.Sprintf("%s/computer/%s/logText/progressiveText?start=%d", rootURL, name, start), nil)
+	response = &http.Response{
+		StatusCode: 200,
+		Request:    request,
+		Header: http.Header{
+			"X-More-Data": []string{fmt.Sprintf("%t", hasMore)},
+			"X-Text-Size": []string{fmt.Sprintf("%d", start+int64(len(text)))},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString(text)),
+	}
+	roundTripper.EXPECT().
+		RoundTrip(core.NewVerboseRequestMatcher(request).WithQuery()).Return(response, nil)
+	if user != "" && password != "" {
+		request.SetBasicAuth(user, password)
+	}
+	return
+}
+
+// PrepareForComputerPageRequest only for test
+func PrepareForComputerPageRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, name, page, body string) {
+	prepareForGet(roundTripper, fmt.Sprintf("%s/computer/%s/%s", rootURL, name, page), user, password, body)
+}
+
+// PrepareForJenkinsVersionRequest only for test
+func PrepareForJenkinsVersionRequest(roundTripper *mhttp.MockRoundTripper, rootURL, user, password, version string) {
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/json?tree=nodeName", rootURL), nil)
+	response := &http.Response{
+		StatusCode: 200,
+		Request:    request,
+		Header:     http.Header{"X-Jenkins": []string{version}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+	}
+	roundTripper.EXPECT().
+		RoundTrip(core.NewVerboseRequestMatcher(request).WithQuery()).Return(response, nil)
+	if user != "" && password != "" {
+		request.SetBasicAuth(user, password)
+	}
+}
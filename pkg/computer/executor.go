@@ -0,0 +1,103 @@
+package computer
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/util"
+)
+
+const executorFields = "[number,idle,likelyStuck,progress,idleStartMilliseconds," +
+	"currentExecutable[number,url,fullDisplayName,timestamp]]"
+
+// executorsTree is the tree query of the executors and one-off executors
+const executorsTree = "executors" + executorFields + ",oneOffExecutors" + executorFields
+
+// Executor is a slot of an agent which runs a build
+type Executor struct {
+	Number      int
+	Idle        bool
+	LikelyStuck bool
+	// Progress is the estimated percentage of the current build, it's -1 if it's unknown or idle
+	Progress              int
+	IdleStartMilliseconds util.Time
+	CurrentExecutable     *Executable
+}
+
+// Executable is the build which is running on an executor
+type Executable struct {
+	Class           string `json:"_class"`
+	Number          int
+	URL             string
+	FullDisplayName string
+	Timestamp       util.Time
+}
+
+// IdleDuration returns how long the executor has been idle, it's zero if it's busy
+func (e *Executor) IdleDuration(now time.Time) time.Duration {
+	if !e.Idle || e.IdleStartMilliseconds.IsZero() {
+		return 0
+	}
+	return now.Sub(e.IdleStartMilliseconds.Time)
+}
+
+// RunningBuild is a build which is running on a node
+type RunningBuild struct {
+	Node     string
+	Executor int
+	// OneOff means the build runs on a flyweight executor, e.g. the outer part of a Pipeline
+	OneOff      bool
+	Progress    int
+	LikelyStuck bool
+	Build       Executable
+}
+
+// GetExecutors returns the executors and one-off executors of a computer
+func (c *Client) GetExecutors(name string) (executors, oneOffExecutors []Executor, err error) {
+	computer := &Computer{}
+	var api string
+	if api, err = c.getNodeAPI(name, "/api/json?tree=%s", executorsTree); err != nil {
+		return
+	}
+	if err = c.RequestWithData(http.MethodGet, api, nil, nil, 200, computer); err == nil {
+		executors, oneOffExecutors = computer.Executors, computer.OneOffExecutors
+	}
+	return
+}
+
+// GetRunningBuilds returns all the running builds on the controller and agents
+func (c *Client) GetRunningBuilds() (builds []RunningBuild, err error) {
+	computers := List{}
+	api := fmt.Sprintf("/computer/api/json?tree=computer[displayName,%s]", executorsTree)
+	if err = c.RequestWithData(http.MethodGet, api, nil, nil, 200, &computers); err == nil {
+		for _, computer := range computers.Computer {
+			builds = append(builds, computer.RunningBuilds()...)
+		}
+	}
+	return
+}
+
+// RunningBuilds returns the running builds on the computer
+func (c *Computer) RunningBuilds() (builds []RunningBuild) {
+	builds = appendRunningBuilds(builds, c.DisplayName, c.Executors, false)
+	builds = appendRunningBuilds(builds, c.DisplayName, c.OneOffExecutors, true)
+	return
+}
+
+func appendRunningBuilds(builds []RunningBuild, node string, executors []Executor, oneOff bool) []RunningBuild {
+	for _, executor := range executors {
+		if executor.CurrentExecutable == nil {
+			continue
+		}
+		builds = append(builds, RunningBuild{
+			Node:        node,
+			Executor:    executor.Number,
+			OneOff:      oneOff,
+			Progress:    executor.Progress,
+			LikelyStuck: executor.LikelyStuck,
+			Build:       *executor.CurrentExecutable,
+		})
+	}
+	return builds
+}
@@ -0,0 +1,150 @@
+package computer
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/util"
+)
+
+// InboundAgent is the settings of running an inbound agent on the current machine
+type InboundAgent struct {
+	Name string
+	// WorkDir is the work directory of the agent, the default one is used if it's empty
+	WorkDir string
+	// JarPath is the path of agent.jar, it will be downloaded into the work directory if it's empty
+	JarPath string
+	// WebSocket connects to Jenkins via WebSocket instead of the TCP port
+	WebSocket bool
+	// Java is the java command, the default value is java
+	Java        string
+	JavaOptions []string
+	// ExecContext is the context of running the command, the os/exec one is used if it's nil
+	ExecContext util.ExecContext
+	// Stdout and Stderr are the output of the agent process, the ones of the current process are used if they're nil
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// DownloadAgentJar downloads agent.jar from Jenkins into the target path
+func (c *Client) DownloadAgentJar(target string) (err error) {
+	var response *http.Response
+	if response, err = c.RequestWithResponse(http.MethodGet, "/jnlpJars/agent.jar", nil, nil); err != nil {
+		return
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(response.Body)
+		err = c.ErrorHandle(response.StatusCode, data)
+		return
+	}
+
+	if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return
+	}
+	// download into a temporary file, then the target is never a broken jar
+	tmp := target + ".download"
+	var file *os.File
+	if file, err = os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644); err != nil {
+		return
+	}
+	if _, err = io.Copy(file, response.Body); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmp)
+		return
+	}
+	if err = file.Close(); err == nil {
+		err = os.Rename(tmp, target)
+	}
+	return
+}
+
+// GetAgentCommand returns the command and the arguments of running an inbound agent.
+// The secret could be a file path which leads with @, then it's not visible in the process list
+func (c *Client) GetAgentCommand(agent InboundAgent, secret string) (name string, args []string) {
+	name = agent.Java
+	if name == "" {
+		name = "java"
+	}
+
+	args = append(args, agent.JavaOptions...)
+	args = append(args, "-jar", agent.jarPath(),
+		"-url", c.URL,
+		"-secret", secret,
+		"-name", agent.Name,
+		"-workDir", agent.workDir())
+	if agent.WebSocket {
+		args = append(args, "-webSocket")
+	}
+	return
+}
+
+// StartAgent fetches the secret and agent.jar, then starts the agent process.
+// The caller could wait for or kill the returned command.
+func (c *Client) StartAgent(agent InboundAgent) (cmd *exec.Cmd, err error) {
+	var secret string
+	if secret, err = c.GetSecret(agent.Name); err != nil {
+		return
+	}
+
+	if agent.JarPath == "" {
+		agent.JarPath = agent.jarPath()
+		if err = c.DownloadAgentJar(agent.JarPath); err != nil {
+			err = fmt.Errorf("cannot download agent.jar, error: %v", err)
+			return
+		}
+	}
+
+	// the secret is passed by a file, the arguments of a process are visible to the other users
+	secretFile := filepath.Join(agent.workDir(), "secret")
+	if err = writeSecretFile(secretFile, secret); err != nil {
+		err = fmt.Errorf("cannot write the secret file, error: %v", err)
+		return
+	}
+
+	name, args := c.GetAgentCommand(agent, "@"+secretFile)
+	cmd = util.ExecCommand(agent.ExecContext, name, args...)
+	cmd.Stdout = agent.Stdout
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	cmd.Stderr = agent.Stderr
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	err = cmd.Start()
+	return
+}
+
+// writeSecretFile writes the secret into a file which is only readable for the current user
+func writeSecretFile(path, secret string) (err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	if err = ioutil.WriteFile(path, []byte(secret), 0600); err == nil {
+		// the permission of an existing file is not changed by WriteFile
+		err = os.Chmod(path, 0600)
+	}
+	return
+}
+
+func (a InboundAgent) workDir() string {
+	if a.WorkDir == "" {
+		return GetDefaultAgentWorkDir()
+	}
+	return a.WorkDir
+}
+
+func (a InboundAgent) jarPath() string {
+	if a.JarPath == "" {
+		return filepath.Join(a.workDir(), "agent.jar")
+	}
+	return a.JarPath
+}
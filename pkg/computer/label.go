@@ -0,0 +1,210 @@
+package computer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/queue"
+)
+
+// LabelNode is a node which has a label
+type LabelNode struct {
+	Class    string `json:"_class"`
+	NodeName string
+}
+
+// LoadStatistics is the load statistics of a label or the whole Jenkins
+type LoadStatistics struct {
+	AvailableExecutors  MultiStageTimeSeries
+	BusyExecutors       MultiStageTimeSeries
+	ConnectingExecutors MultiStageTimeSeries
+	DefinedExecutors    MultiStageTimeSeries
+	IdleExecutors       MultiStageTimeSeries
+	OnlineExecutors     MultiStageTimeSeries
+	QueueLength         MultiStageTimeSeries
+	TotalExecutors      MultiStageTimeSeries
+	TotalQueueLength    MultiStageTimeSeries
+}
+
+// MultiStageTimeSeries is the time series in different time spans
+type MultiStageTimeSeries struct {
+	// Sec10 is the time series in the interval of 10 seconds
+	Sec10 TimeSeries
+	// Min is the time series in the interval of one minute
+	Min TimeSeries
+	// Hour is the time series in the interval of one hour
+	Hour TimeSeries
+}
+
+// TimeSeries is the exponential moving average of data, the latest one is the first of history
+type TimeSeries struct {
+	Latest  float64
+	History []float64
+}
+
+// GetLabel returns a label by the label expression, e.g. linux && docker
+func (c *Client) GetLabel(expression string) (label *Label, err error) {
+	api := fmt.Sprintf("/label/%s/api/json", url.PathEscape(expression))
+	err = c.RequestWithData(http.MethodGet, api, nil, nil, 200, &label)
+	return
+}
+
+// GetLabelLoad returns the load statistics of a label expression
+func (c *Client) GetLabelLoad(expression string) (load *LoadStatistics, err error) {
+	api := fmt.Sprintf("/label/%s/loadStatistics/api/json?depth=1", url.PathEscape(expression))
+	err = c.RequestWithData(http.MethodGet, api, nil, nil, 200, &load)
+	return
+}
+
+// GetLabelQueue returns the queue items which are waiting for the label.
+// It only works with the English messages of Jenkins, see also queue.Item.Blockage
+func (c *Client) GetLabelQueue(label string) (items []queue.Item, err error) {
+	queueClient := &queue.Client{JenkinsCore: c.JenkinsCore}
+	var jobQueue *queue.JobQueue
+	if jobQueue, err = queueClient.Get(); err == nil && jobQueue != nil {
+		filter := queue.FilterByLabel(label)
+		for i := range jobQueue.Items {
+			if filter(&jobQueue.Items[i]) {
+				items = append(items, jobQueue.Items[i])
+			}
+		}
+	}
+	return
+}
+
+// AddLabels adds labels to an agent, only the label element of the config is changed
+func (c *Client) AddLabels(name string, labels ...string) error {
+	return c.updateLabels(name, func(current []string) []string {
+		for _, label := range labels {
+			if !containsString(current, label) {
+				current = append(current, label)
+			}
+		}
+		return current
+	})
+}
+
+// RemoveLabels removes labels from an agent, only the label element of the config is changed
+func (c *Client) RemoveLabels(name string, labels ...string) error {
+	return c.updateLabels(name, func(current []string) (result []string) {
+		for _, label := range current {
+			if !containsString(labels, label) {
+				result = append(result, label)
+			}
+		}
+		return
+	})
+}
+
+func (c *Client) updateLabels(name string, update func([]string) []string) (err error) {
+	var config string
+	if config, err = c.GetConfig(name); err != nil {
+		return
+	}
+
+	var newConfig string
+	if newConfig, err = replaceLabels(config, update); err == nil && newConfig != config {
+		err = c.UpdateConfig(name, newConfig)
+	}
+	return
+}
+
+// replaceLabels replaces the label element of the config.xml of an agent, only the direct child of the
+// root element is the label of the agent, e.g. the label of a tool installer is kept
+func replaceLabels(config string, update func([]string) []string) (result string, err error) {
+	var element *labelElement
+	if element, err = findLabelElement(config); err != nil {
+		err = fmt.Errorf("invalid config of agent: %v", err)
+		return
+	}
+	current := strings.Fields(element.text)
+
+	newLabels := update(current)
+	if strings.Join(newLabels, " ") == strings.Join(current, " ") {
+		result = config
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err = xml.EscapeText(buf, []byte(strings.Join(newLabels, " "))); err != nil {
+		return
+	}
+	newElement := fmt.Sprintf("<label>%s</label>", buf.String())
+
+	if element.found {
+		result = config[:element.start] + newElement + config[element.end:]
+	} else {
+		result = config[:element.start] + "  " + newElement + "\n" + config[element.start:]
+	}
+	return
+}
+
+// labelElement is the position of the label element in the config, the start is the position
+// of the end tag of the root element if the label element is not found
+type labelElement struct {
+	found      bool
+	start, end int
+	text       string
+}
+
+// findLabelElement finds the label element which is the direct child of the root element
+func findLabelElement(config string) (element *labelElement, err error) {
+	// the XML declaration is skipped, the decoder doesn't support XML 1.1 which is used by Jenkins
+	base := 0
+	if strings.HasPrefix(config, "<?xml") {
+		if base = strings.Index(config, "?>"); base < 0 {
+			err = fmt.Errorf("invalid XML declaration")
+			return
+		}
+		base += len("?>")
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(config[base:]))
+	element = &labelElement{}
+	depth := 0
+	for {
+		offset := int(decoder.InputOffset())
+		var token xml.Token
+		if token, err = decoder.Token(); err != nil {
+			if err == io.EOF {
+				err = fmt.Errorf("the root element is not closed")
+			}
+			return
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 && t.Name.Local == "label" && !element.found {
+				element.found = true
+				element.start = base + offset
+				if err = decoder.DecodeElement(&element.text, &t); err != nil {
+					return
+				}
+				element.end = base + int(decoder.InputOffset())
+				depth--
+			}
+		case xml.EndElement:
+			if depth--; depth == 0 {
+				if !element.found {
+					element.start = base + offset
+				}
+				return
+			}
+		}
+	}
+}
+
+func containsString(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
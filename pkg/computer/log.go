@@ -0,0 +1,104 @@
+package computer
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/job"
+)
+
+// GetLogFrom returns the log of an agent from the start offset, it's the same as the build log
+func (c *Client) GetLogFrom(name string, start int64) (log job.Log, err error) {
+	var (
+		api      string
+		response *http.Response
+	)
+	if api, err = c.getNodeAPI(name, "/logText/progressiveText?start=%d", start); err != nil {
+		return
+	}
+	if response, err = c.RequestWithResponse(http.MethodGet, api, nil, nil); err != nil {
+		return
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	var data []byte
+	if data, err = ioutil.ReadAll(response.Body); err == nil {
+		if response.StatusCode != http.StatusOK {
+			err = c.ErrorHandle(response.StatusCode, data)
+			return
+		}
+		log.Text = string(data)
+		log.HasMore = strings.ToLower(response.Header.Get("X-More-Data")) == "true"
+		if log.NextStart, err = strconv.ParseInt(response.Header.Get("X-Text-Size"), 10, 64); err != nil {
+			// keep the offset if the size is missing
+			log.NextStart, err = start+int64(len(data)), nil
+		}
+	}
+	return
+}
+
+// FollowLog writes the log of an agent to the writer until there's no more data or the context is done.
+// The log of an agent usually keeps growing, so it's common to stop by the context.
+// The interval less than 1 means 5 seconds.
+func (c *Client) FollowLog(ctx context.Context, name string, interval time.Duration, writer io.Writer) (err error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	var (
+		start int64
+		log   job.Log
+	)
+	for {
+		if log, err = c.GetLogFrom(name, start); err != nil {
+			return
+		}
+		if _, err = io.WriteString(writer, log.Text); err != nil || !log.HasMore {
+			return
+		}
+		start = log.NextStart
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// GetSystemInfo returns the system info page of an agent, it contains the system properties
+// and the environment variables. It requires the administer permission
+func (c *Client) GetSystemInfo(name string) (info string, err error) {
+	return c.getNodeText(name, "/systemInfo")
+}
+
+// GetThreadDump returns the thread dump page of an agent, it requires the administer permission
+func (c *Client) GetThreadDump(name string) (dump string, err error) {
+	return c.getNodeText(name, "/threadDump")
+}
+
+// getNodeText returns the text of a page of a node
+func (c *Client) getNodeText(name, page string) (text string, err error) {
+	var (
+		api        string
+		statusCode int
+		data       []byte
+	)
+	if api, err = c.getNodeAPI(name, page); err != nil {
+		return
+	}
+	if statusCode, data, err = c.Request(http.MethodGet, api, nil, nil); err == nil {
+		if statusCode == http.StatusOK {
+			text = string(data)
+		} else {
+			err = c.ErrorHandle(statusCode, data)
+		}
+	}
+	return
+}
@@ -0,0 +1,176 @@
+package computer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/util"
+)
+
+const (
+	// MonitorDiskSpace is the name of the disk space monitor
+	MonitorDiskSpace = "hudson.node_monitors.DiskSpaceMonitor"
+	// MonitorTemporarySpace is the name of the temporary space monitor
+	MonitorTemporarySpace = "hudson.node_monitors.TemporarySpaceMonitor"
+	// MonitorSwapSpace is the name of the swap space monitor
+	MonitorSwapSpace = "hudson.node_monitors.SwapSpaceMonitor"
+	// MonitorArchitecture is the name of the architecture monitor
+	MonitorArchitecture = "hudson.node_monitors.ArchitectureMonitor"
+	// MonitorResponseTime is the name of the response time monitor
+	MonitorResponseTime = "hudson.node_monitors.ResponseTimeMonitor"
+	// MonitorClock is the name of the clock difference monitor
+	MonitorClock = "hudson.node_monitors.ClockMonitor"
+)
+
+// MonitorData is the data of the node monitors, the value is nil if the data is not available
+type MonitorData struct {
+	DiskSpace       *DiskSpace       `json:"hudson.node_monitors.DiskSpaceMonitor"`
+	TemporarySpace  *DiskSpace       `json:"hudson.node_monitors.TemporarySpaceMonitor"`
+	SwapSpace       *SwapSpace       `json:"hudson.node_monitors.SwapSpaceMonitor"`
+	Architecture    string           `json:"hudson.node_monitors.ArchitectureMonitor"`
+	ResponseTime    *ResponseTime    `json:"hudson.node_monitors.ResponseTimeMonitor"`
+	ClockDifference *ClockDifference `json:"hudson.node_monitors.ClockMonitor"`
+}
+
+// DiskSpace is the free space of a path
+type DiskSpace struct {
+	Path string
+	// Size is the free space in bytes
+	Size      int64
+	Timestamp util.Time
+}
+
+// SwapSpace is the memory usage of a node
+type SwapSpace struct {
+	AvailablePhysicalMemory int64
+	AvailableSwapSpace      int64
+	TotalPhysicalMemory     int64
+	TotalSwapSpace          int64
+}
+
+// ResponseTime is the response time of a node
+type ResponseTime struct {
+	// Average is the average response time in milliseconds
+	Average   int64
+	Timestamp util.Time
+}
+
+// Duration returns the average response time
+func (r *ResponseTime) Duration() time.Duration {
+	return time.Duration(r.Average) * time.Millisecond
+}
+
+// ClockDifference is the clock difference between the controller and a node
+type ClockDifference struct {
+	// Diff is the difference in milliseconds, it's positive if the node is ahead
+	Diff int64
+}
+
+// Duration returns the absolute clock difference
+func (c *ClockDifference) Duration() time.Duration {
+	diff := c.Diff
+	if diff < 0 {
+		diff = -diff
+	}
+	return time.Duration(diff) * time.Millisecond
+}
+
+// HealthThresholds are the thresholds of an unhealthy node, zero means no checking
+type HealthThresholds struct {
+	// MinDiskSpace is the min free space of the remote FS in bytes
+	MinDiskSpace int64
+	// MinTemporarySpace is the min free space of the temporary directory in bytes
+	MinTemporarySpace  int64
+	MaxResponseTime    time.Duration
+	MaxClockDifference time.Duration
+}
+
+// DefaultHealthThresholds returns the same thresholds as Jenkins
+func DefaultHealthThresholds() HealthThresholds {
+	return HealthThresholds{
+		MinDiskSpace:       1024 * 1024 * 1024,
+		MinTemporarySpace:  1024 * 1024 * 1024,
+		MaxResponseTime:    5 * time.Second,
+		MaxClockDifference: 5 * time.Second,
+	}
+}
+
+// HealthIssue is a problem of a node
+type HealthIssue struct {
+	// Monitor is the name of the monitor which found the issue
+	Monitor string
+	Message string
+}
+
+// Health is the health of a node
+type Health struct {
+	Node    string
+	Offline bool
+	Issues  []HealthIssue
+}
+
+// IsHealthy returns true if there's no issue
+func (h *Health) IsHealthy() bool {
+	return len(h.Issues) == 0
+}
+
+// CheckHealth evaluates the health of all the computers by the monitor data
+func (c *Client) CheckHealth(thresholds HealthThresholds) (healths []Health, err error) {
+	var computers List
+	if computers, err = c.List(); err == nil {
+		for i := range computers.Computer {
+			healths = append(healths, computers.Computer[i].CheckHealth(thresholds))
+		}
+	}
+	return
+}
+
+// CheckHealth evaluates the health of the computer by the monitor data, the missing data is skipped
+func (c *Computer) CheckHealth(thresholds HealthThresholds) (health Health) {
+	health = Health{
+		Node:    c.DisplayName,
+		Offline: c.Offline,
+	}
+	data := c.MonitorData
+
+	if space := data.DiskSpace; space != nil && thresholds.MinDiskSpace > 0 && space.Size < thresholds.MinDiskSpace {
+		health.Issues = append(health.Issues, HealthIssue{
+			Monitor: MonitorDiskSpace,
+			Message: fmt.Sprintf("the free space of %s is %s", space.Path, formatBytes(space.Size)),
+		})
+	}
+	if space := data.TemporarySpace; space != nil && thresholds.MinTemporarySpace > 0 && space.Size < thresholds.MinTemporarySpace {
+		health.Issues = append(health.Issues, HealthIssue{
+			Monitor: MonitorTemporarySpace,
+			Message: fmt.Sprintf("the free space of %s is %s", space.Path, formatBytes(space.Size)),
+		})
+	}
+	if response := data.ResponseTime; response != nil && thresholds.MaxResponseTime > 0 &&
+		response.Duration() > thresholds.MaxResponseTime {
+		health.Issues = append(health.Issues, HealthIssue{
+			Monitor: MonitorResponseTime,
+			Message: fmt.Sprintf("the average response time is %s", response.Duration()),
+		})
+	}
+	if clock := data.ClockDifference; clock != nil && thresholds.MaxClockDifference > 0 &&
+		clock.Duration() > thresholds.MaxClockDifference {
+		health.Issues = append(health.Issues, HealthIssue{
+			Monitor: MonitorClock,
+			Message: fmt.Sprintf("the clock difference is %s", time.Duration(clock.Diff)*time.Millisecond),
+		})
+	}
+	return
+}
+
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
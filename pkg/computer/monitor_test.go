@@ -0,0 +1,44 @@
+package computer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/computer"
+)
+
+func TestComputer_CheckHealth(t *testing.T) {
+	node := &computer.Computer{
+		DisplayName: "agent",
+		MonitorData: computer.MonitorData{
+			DiskSpace:       &computer.DiskSpace{Path: "/home/jenkins", Size: 512 * 1024 * 1024},
+			TemporarySpace:  &computer.DiskSpace{Path: "/tmp", Size: 2 * 1024 * 1024 * 1024},
+			ResponseTime:    &computer.ResponseTime{Average: 6000},
+			ClockDifference: &computer.ClockDifference{Diff: -6000},
+		},
+	}
+
+	health := node.CheckHealth(computer.DefaultHealthThresholds())
+	if health.IsHealthy() || len(health.Issues) != 3 {
+		t.Fatalf("unexpected health: %+v", health)
+	}
+	if health.Issues[0].Monitor != computer.MonitorDiskSpace || health.Issues[0].Message != "the free space of /home/jenkins is 512.0 MiB" {
+		t.Errorf("unexpected disk space issue: %+v", health.Issues[0])
+	}
+	if health.Issues[1].Monitor != computer.MonitorResponseTime {
+		t.Errorf("unexpected response time issue: %+v", health.Issues[1])
+	}
+	if health.Issues[2].Monitor != computer.MonitorClock || health.Issues[2].Message != "the clock difference is -6s" {
+		t.Errorf("unexpected clock issue: %+v", health.Issues[2])
+	}
+
+	health = node.CheckHealth(computer.HealthThresholds{MaxClockDifference: 10 * time.Second})
+	if !health.IsHealthy() {
+		t.Errorf("unexpected health with custom thresholds: %+v", health)
+	}
+
+	health = (&computer.Computer{Offline: true}).CheckHealth(computer.DefaultHealthThresholds())
+	if !health.IsHealthy() || !health.Offline {
+		t.Errorf("the node without monitor data should be skipped: %+v", health)
+	}
+}
@@ -0,0 +1,108 @@
+package computer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// builtInNode is the URL name of the built-in node since Jenkins 2.307
+	builtInNode = "(built-in)"
+	// legacyBuiltInNode is the URL name of the built-in node before Jenkins 2.307
+	legacyBuiltInNode = "(master)"
+)
+
+// NodeRef references a node by its name, or the built-in node of the controller
+type NodeRef struct {
+	name    string
+	builtIn bool
+}
+
+// BuiltInNode references the built-in node, its URL name depends on the version of Jenkins
+var BuiltInNode = NodeRef{builtIn: true}
+
+// NodeName references a node by its name.
+// The empty name and the URL names of the built-in node, (built-in) and (master), reference the built-in node
+func NodeName(name string) NodeRef {
+	switch name {
+	case "", builtInNode, legacyBuiltInNode:
+		return BuiltInNode
+	}
+	return NodeRef{name: name}
+}
+
+// IsBuiltIn returns true if it references the built-in node
+func (r NodeRef) IsBuiltIn() bool {
+	return r.builtIn
+}
+
+// String returns the name of the node, it's empty for the built-in node
+func (r NodeRef) String() string {
+	return r.name
+}
+
+// GetNodePath returns the path of a node which leads with slash, e.g. /computer/agent.
+// The version of Jenkins is fetched to resolve the built-in node, nothing is kept in the client
+func (c *Client) GetNodePath(ref NodeRef) (path string, err error) {
+	if !ref.IsBuiltIn() {
+		path = "/computer/" + url.PathEscape(ref.name)
+		return
+	}
+
+	var name string
+	if name, err = c.getBuiltInNode(); err == nil {
+		path = "/computer/" + name
+	}
+	return
+}
+
+// getBuiltInNode returns the URL name of the built-in node by the version of Jenkins
+func (c *Client) getBuiltInNode() (name string, err error) {
+	var (
+		response *http.Response
+		data     []byte
+	)
+	if response, err = c.RequestWithResponse(http.MethodGet, "/api/json?tree=nodeName", nil, nil); err != nil {
+		return
+	}
+	data, _ = ioutil.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		err = c.ErrorHandle(response.StatusCode, data)
+		return
+	}
+	name = getBuiltInNodeName(response.Header.Get("X-Jenkins"))
+	return
+}
+
+// getBuiltInNodeName returns the URL name of the built-in node by the version of Jenkins,
+// the new name is used if the version is unknown
+func getBuiltInNodeName(version string) string {
+	segments := strings.SplitN(version, ".", 3)
+	if len(segments) < 2 {
+		return builtInNode
+	}
+
+	major, majorErr := strconv.Atoi(segments[0])
+	minor, minorErr := strconv.Atoi(strings.SplitN(segments[1], "-", 2)[0])
+	if majorErr != nil || minorErr != nil {
+		return builtInNode
+	}
+	if major < 2 || (major == 2 && minor < 307) {
+		return legacyBuiltInNode
+	}
+	return builtInNode
+}
+
+// getNodeAPI returns the API of a node by name, the format is the rest of the path
+func (c *Client) getNodeAPI(name, format string, args ...interface{}) (api string, err error) {
+	var path string
+	if path, err = c.GetNodePath(NodeName(name)); err == nil {
+		api = path + fmt.Sprintf(format, args...)
+	}
+	return
+}
@@ -0,0 +1,35 @@
+package computer
+
+import "testing"
+
+func TestGetBuiltInNodeName(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "2.289.3", want: "(master)"},
+		{version: "2.306", want: "(master)"},
+		{version: "2.307", want: "(built-in)"},
+		{version: "2.319.1", want: "(built-in)"},
+		{version: "2.320-SNAPSHOT", want: "(built-in)"},
+		{version: "1.651.3", want: "(master)"},
+		{version: "", want: "(built-in)"},
+		{version: "unknown", want: "(built-in)"},
+	}
+	for _, tt := range tests {
+		if got := getBuiltInNodeName(tt.version); got != tt.want {
+			t.Errorf("getBuiltInNodeName(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestNodeName(t *testing.T) {
+	for _, name := range []string{"", "(built-in)", "(master)"} {
+		if !NodeName(name).IsBuiltIn() {
+			t.Errorf("NodeName(%q) should reference the built-in node", name)
+		}
+	}
+	if ref := NodeName("master"); ref.IsBuiltIn() || ref.String() != "master" {
+		t.Errorf("NodeName(master) = %+v, want a regular node", ref)
+	}
+}
@@ -0,0 +1,94 @@
+package computer
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Get returns a computer by name
+func (c *Client) Get(name string) (computer *Computer, err error) {
+	var api string
+	if api, err = c.getNodeAPI(name, "/api/json"); err == nil {
+		err = c.RequestWithData(http.MethodGet, api, nil, nil, 200, &computer)
+	}
+	return
+}
+
+// ToggleOffline switches a computer between temporarily offline and online, the message is the offline reason
+func (c *Client) ToggleOffline(name, message string) (err error) {
+	var api string
+	if api, err = c.getNodeAPI(name, "/toggleOffline?offlineMessage=%s", url.QueryEscape(message)); err == nil {
+		_, err = c.RequestWithoutData(http.MethodPost, api, nil, nil, 200)
+	}
+	return
+}
+
+// ChangeOfflineCause changes the reason of a temporarily offline computer
+func (c *Client) ChangeOfflineCause(name, message string) (err error) {
+	var api string
+	if api, err = c.getNodeAPI(name, "/changeOfflineCause?offlineMessage=%s", url.QueryEscape(message)); err == nil {
+		_, err = c.RequestWithoutData(http.MethodPost, api, nil, nil, 200)
+	}
+	return
+}
+
+// Disconnect disconnects a computer, the running builds will be aborted
+func (c *Client) Disconnect(name, message string) (err error) {
+	var api string
+	if api, err = c.getNodeAPI(name, "/doDisconnect?offlineMessage=%s", url.QueryEscape(message)); err == nil {
+		_, err = c.RequestWithoutData(http.MethodPost, api, nil, nil, 200)
+	}
+	return
+}
+
+// SetOffline marks a computer as temporarily offline, the reason is changed if it's offline already
+func (c *Client) SetOffline(name, message string) (err error) {
+	var computer *Computer
+	if computer, err = c.Get(name); err == nil {
+		if computer.TemporarilyOffline {
+			err = c.ChangeOfflineCause(name, message)
+		} else {
+			err = c.ToggleOffline(name, message)
+		}
+	}
+	return
+}
+
+// SetOnline brings a temporarily offline computer back online, it does nothing if it's online already
+func (c *Client) SetOnline(name string) (err error) {
+	var computer *Computer
+	if computer, err = c.Get(name); err == nil && computer.TemporarilyOffline {
+		err = c.ToggleOffline(name, "")
+	}
+	return
+}
+
+// Drain marks a computer as temporarily offline, then waits until all the executors are idle.
+// The new builds will not be scheduled to it, the running builds are kept.
+// The interval less than 1 means 5 seconds, the timeout less than 1 means waiting forever.
+func (c *Client) Drain(name, message string, interval, timeout time.Duration) (err error) {
+	if err = c.SetOffline(name, message); err != nil {
+		return
+	}
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	var computer *Computer
+	for {
+		if computer, err = c.Get(name); err != nil || computer.Idle {
+			return
+		}
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			err = fmt.Errorf("timeout waiting for computer %s to be idle", name)
+			return
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,294 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"go.uber.org/zap"
+)
+
+// Client is the client of the SSE gateway which is used by BlueOcean.
+// It's safe to subscribe concurrently, every subscription has its own HTTP session.
+type Client struct {
+	core.JenkinsCore
+
+	// ClientID is the identity of the connection, a random one will be used if it's empty
+	ClientID string
+	// RetryInterval is the waiting time before reconnecting, the default value is 5 seconds
+	RetryInterval time.Duration
+}
+
+// Subscribe connects to the SSE gateway and subscribes the events.
+// The error of the first connection will be returned, then it reconnects and resubscribes
+// the events until the context is done. The channel will be closed once the context is done.
+func (c *Client) Subscribe(ctx context.Context, subscriptions ...Subscription) (events <-chan Event, err error) {
+	clientID := c.ClientID
+	if clientID == "" {
+		clientID = fmt.Sprintf("jenkins-client-%d", time.Now().UnixNano())
+	}
+
+	var stream *eventStream
+	if stream, err = c.connect(ctx, clientID, subscriptions); err != nil {
+		return
+	}
+
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		for {
+			if err := c.dispatch(ctx, stream.Reader, ch); err != nil {
+				core.Logger.Debug("the SSE gateway stream was broken", zap.Error(err))
+			}
+			_ = stream.Close()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(c.getRetryInterval()):
+				}
+
+				var err error
+				if stream, err = c.connect(ctx, clientID, subscriptions); err == nil {
+					break
+				}
+				core.Logger.Debug("cannot reconnect to the SSE gateway", zap.Error(err))
+			}
+		}
+	}()
+	events = ch
+	return
+}
+
+func (c *Client) getRetryInterval() time.Duration {
+	if c.RetryInterval <= 0 {
+		return 5 * time.Second
+	}
+	return c.RetryInterval
+}
+
+// eventStream is the body of the SSE gateway response
+type eventStream struct {
+	*bufio.Reader
+	io.Closer
+}
+
+// session is a connection to the SSE gateway, the gateway keeps the subscriptions in the HTTP session
+type session struct {
+	core.JenkinsCore
+
+	client  *http.Client
+	batchID int
+}
+
+// connect opens the event stream in a new session, then subscribes the events after the dispatcher was ready
+func (c *Client) connect(ctx context.Context, clientID string, subscriptions []Subscription) (stream *eventStream, err error) {
+	var jar http.CookieJar
+	if jar, err = cookiejar.New(nil); err != nil {
+		return
+	}
+	s := &session{JenkinsCore: c.JenkinsCore, client: c.GetClient()}
+	s.client.Jar = jar
+	s.client.Timeout = 0
+
+	clientID = url.QueryEscape(clientID)
+	var response *http.Response
+	if response, err = s.do(ctx, http.MethodGet, fmt.Sprintf("/sse-gateway/connect?clientId=%s", clientID), nil, nil); err != nil {
+		return
+	}
+	_ = response.Body.Close()
+
+	if response, err = s.do(ctx, http.MethodGet, fmt.Sprintf("/sse-gateway/listen/%s", clientID), map[string]string{
+		"Accept": "text/event-stream",
+	}, nil); err != nil {
+		return
+	}
+	stream = &eventStream{Reader: bufio.NewReader(response.Body), Closer: response.Body}
+
+	var dispatcherID string
+	if dispatcherID, err = readDispatcherID(stream.Reader); err == nil {
+		err = s.configure(ctx, dispatcherID, subscriptions)
+	}
+	if err != nil {
+		_ = stream.Close()
+		stream = nil
+	}
+	return
+}
+
+func (s *session) configure(ctx context.Context, dispatcherID string, subscriptions []Subscription) (err error) {
+	config := struct {
+		DispatcherID string         `json:"dispatcherId"`
+		Subscribe    []Subscription `json:"subscribe"`
+	}{
+		DispatcherID: dispatcherID,
+		Subscribe:    subscriptions,
+	}
+	var payload []byte
+	if payload, err = json.Marshal(config); err != nil {
+		return
+	}
+
+	s.batchID++
+	var response *http.Response
+	if response, err = s.do(ctx, http.MethodPost, fmt.Sprintf("/sse-gateway/configure?batchId=%d", s.batchID), map[string]string{
+		"Content-Type": "application/json",
+	}, bytes.NewReader(payload)); err == nil {
+		_ = response.Body.Close()
+	}
+	return
+}
+
+// do sends the request in the session, it makes sure the response is successful
+func (s *session) do(ctx context.Context, method, api string, headers map[string]string, payload io.Reader) (
+	response *http.Response, err error) {
+	var req *http.Request
+	if req, err = s.newRequest(ctx, method, api, payload); err != nil {
+		return
+	}
+	// the crumb is bound to the HTTP session, so it cannot be fetched by AuthHandle
+	if method == http.MethodPost {
+		var crumb *core.JenkinsCrumb
+		if crumb, err = s.getCrumb(ctx); err != nil {
+			return
+		}
+		if crumb != nil {
+			req.Header.Add(crumb.CrumbRequestField, crumb.Crumb)
+		}
+	}
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+
+	if response, err = s.client.Do(req); err == nil && response.StatusCode != http.StatusOK {
+		var data []byte
+		data, _ = ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		err = s.ErrorHandle(response.StatusCode, data)
+	}
+	return
+}
+
+// getCrumb returns the crumb of the session, it's nil if the crumb is disabled
+func (s *session) getCrumb(ctx context.Context) (crumb *core.JenkinsCrumb, err error) {
+	var (
+		req      *http.Request
+		response *http.Response
+		data     []byte
+	)
+	if req, err = s.newRequest(ctx, http.MethodGet, "/crumbIssuer/api/json", nil); err != nil {
+		return
+	}
+	if response, err = s.client.Do(req); err != nil {
+		return
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if data, err = ioutil.ReadAll(response.Body); err == nil {
+		switch response.StatusCode {
+		case http.StatusOK:
+			err = json.Unmarshal(data, &crumb)
+		case http.StatusNotFound:
+			// the crumb is disabled
+		default:
+			err = s.ErrorHandle(response.StatusCode, data)
+		}
+	}
+	return
+}
+
+// newRequest creates a request with the auth of Jenkins, but without the crumb
+func (s *session) newRequest(ctx context.Context, method, api string, payload io.Reader) (req *http.Request, err error) {
+	if req, err = http.NewRequest(method, fmt.Sprintf("%s%s", s.URL, api), payload); err == nil {
+		req = req.WithContext(ctx)
+		if s.UserName != "" && s.Token != "" {
+			req.SetBasicAuth(s.UserName, s.Token)
+		}
+		s.ProxyHandle(req)
+	}
+	return
+}
+
+// dispatch sends the events of the stream to the channel until the stream is broken
+func (c *Client) dispatch(ctx context.Context, reader *bufio.Reader, ch chan<- Event) error {
+	for {
+		name, data, err := readMessage(reader)
+		if err != nil {
+			return err
+		}
+		if name != ChannelJob && name != ChannelPipeline {
+			continue
+		}
+
+		event := Event{Raw: json.RawMessage(data)}
+		if err = json.Unmarshal([]byte(data), &event); err != nil {
+			core.Logger.Debug("cannot parse the event", zap.String("data", data), zap.Error(err))
+			continue
+		}
+		select {
+		case ch <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// readDispatcherID waits for the open message which has the dispatcher id
+func readDispatcherID(reader *bufio.Reader) (dispatcherID string, err error) {
+	var name, data string
+	for {
+		if name, data, err = readMessage(reader); err != nil {
+			return
+		}
+		if name == "open" {
+			open := struct {
+				DispatcherID string `json:"dispatcherId"`
+			}{}
+			if err = json.Unmarshal([]byte(data), &open); err == nil && open.DispatcherID == "" {
+				err = fmt.Errorf("cannot find the dispatcher id from the SSE gateway")
+			}
+			dispatcherID = open.DispatcherID
+			return
+		}
+	}
+}
+
+// readMessage reads a message of the server-sent events
+func readMessage(reader *bufio.Reader) (name, data string, err error) {
+	var lines []string
+	for {
+		var line string
+		if line, err = reader.ReadString('\n'); err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			if len(lines) > 0 {
+				data = strings.Join(lines, "\n")
+				return
+			}
+			// comments and keep-alive messages only
+			name = ""
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			lines = append(lines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
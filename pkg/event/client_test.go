@@ -0,0 +1,132 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("event client test", func() {
+	var (
+		ctrl         *gomock.Controller
+		client       Client
+		roundTripper *mhttp.MockRoundTripper
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		roundTripper = mhttp.NewMockRoundTripper(ctrl)
+		client = Client{
+			ClientID:      "fake",
+			RetryInterval: time.Minute,
+		}
+		client.RoundTripper = roundTripper
+		client.URL = "http://localhost"
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	givenGet := func(api string, headers map[string]string, statusCode int, body string) (response *http.Response) {
+		request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", client.URL, api), nil)
+		for k, v := range headers {
+			request.Header.Set(k, v)
+		}
+		response = &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{},
+			Request:    request,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+		roundTripper.EXPECT().
+			RoundTrip(core.NewVerboseRequestMatcher(request).WithQuery()).Return(response, nil)
+		return
+	}
+
+	Context("Subscribe", func() {
+		It("receive the job events", func() {
+			session := map[string]string{"Cookie": "JSESSIONID=session-1"}
+			givenGet("/sse-gateway/connect?clientId=fake", nil, 200, `{"status":"OK"}`).
+				Header.Set("Set-Cookie", "JSESSIONID=session-1; Path=/")
+			givenGet("/sse-gateway/listen/fake", map[string]string{
+				"Accept": "text/event-stream",
+				"Cookie": "JSESSIONID=session-1",
+			}, 200, `event: open
+data: {"dispatcherId":"dispatcher-1"}
+
+: keep alive
+
+event: job
+data: {"jenkins_channel":"job","jenkins_event":"job_run_queue_enter","job_name":"fake","job_run_queueId":"3"}
+
+event: job
+data: {"jenkins_channel":"job","jenkins_event":"job_run_ended","job_name":"fake","job_run_status":"SUCCESS"}
+
+`)
+			request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/sse-gateway/configure?batchId=1", client.URL),
+				bytes.NewBufferString(`{"dispatcherId":"dispatcher-1","subscribe":[{"jenkins_channel":"job"}]}`))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Cookie", "JSESSIONID=session-1")
+			// the crumb is bound to the session of the gateway
+			givenGet("/crumbIssuer/api/json", session, 200,
+				`{"crumbRequestField":"CrumbRequestField","crumb":"Crumb"}`)
+			request.Header.Set("CrumbRequestField", "Crumb")
+			roundTripper.EXPECT().
+				RoundTrip(core.NewVerboseRequestMatcher(request).WithQuery().WithBody()).
+				Return(&http.Response{StatusCode: 200, Request: request, Body: ioutil.NopCloser(bytes.NewBufferString(""))}, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			events, err := client.Subscribe(ctx, JobSubscription())
+			Expect(err).To(BeNil())
+
+			event := <-events
+			Expect(event.Type).To(Equal(QueueEnter))
+			Expect(event.Type.IsQueueEvent()).To(BeTrue())
+			Expect(event.JobRunQueueID).To(Equal("3"))
+			Expect(string(event.Raw)).To(ContainSubstring(`"job_name":"fake"`))
+
+			event = <-events
+			Expect(event.Type).To(Equal(JobRunEnded))
+			Expect(event.JobRunStatus).To(Equal("SUCCESS"))
+
+			cancel()
+			Eventually(events).Should(BeClosed())
+		})
+
+		It("cannot connect to the gateway", func() {
+			givenGet("/sse-gateway/connect?clientId=fake", nil, 404, "")
+			_, err := client.Subscribe(context.Background(), PipelineSubscription())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("readMessage", func() {
+		It("multiple lines data", func() {
+			reader := bufio.NewReader(strings.NewReader("event: pipeline\r\ndata: a\r\ndata: b\r\n\r\n"))
+			name, data, err := readMessage(reader)
+			Expect(err).To(BeNil())
+			Expect(name).To(Equal("pipeline"))
+			Expect(data).To(Equal("a\nb"))
+		})
+	})
+
+	Context("QueueSubscriptions", func() {
+		It("filter by the event type", func() {
+			subscriptions := QueueSubscriptions()
+			Expect(subscriptions).To(HaveLen(5))
+			Expect(subscriptions[0]).To(Equal(Subscription{"jenkins_channel": ChannelJob, "jenkins_event": string(QueueEnter)}))
+		})
+	})
+})
@@ -0,0 +1,112 @@
+package event
+
+import "encoding/json"
+
+const (
+	// ChannelJob is the channel of job events, the queue events are published on it as well
+	ChannelJob = "job"
+	// ChannelPipeline is the channel of Pipeline events
+	ChannelPipeline = "pipeline"
+)
+
+// Type is the type of a Jenkins event
+type Type string
+
+const (
+	// JobCreated means a job was created
+	JobCreated Type = "job_crud_created"
+	// JobUpdated means a job was updated
+	JobUpdated Type = "job_crud_updated"
+	// JobDeleted means a job was deleted
+	JobDeleted Type = "job_crud_deleted"
+	// JobRenamed means a job was renamed
+	JobRenamed Type = "job_crud_renamed"
+	// JobRunStarted means a build was started
+	JobRunStarted Type = "job_run_started"
+	// JobRunEnded means a build was finished
+	JobRunEnded Type = "job_run_ended"
+	// JobRunPaused means a build was paused, e.g. waiting for an input
+	JobRunPaused Type = "job_run_paused"
+	// JobRunUnpaused means a build was resumed
+	JobRunUnpaused Type = "job_run_unpaused"
+	// QueueEnter means a build entered the queue
+	QueueEnter Type = "job_run_queue_enter"
+	// QueueBuildable means a queued build is ready for an executor
+	QueueBuildable Type = "job_run_queue_buildable"
+	// QueueBlocked means a queued build is blocked
+	QueueBlocked Type = "job_run_queue_blocked"
+	// QueueLeft means a build left the queue
+	QueueLeft Type = "job_run_queue_left"
+	// QueueTaskComplete means a queued task was completed
+	QueueTaskComplete Type = "job_run_queue_task_complete"
+	// PipelineStage means a stage of Pipeline was started
+	PipelineStage Type = "pipeline_stage"
+	// PipelineStep means a step of Pipeline was started
+	PipelineStep Type = "pipeline_step"
+	// PipelineBlockStart means a block of Pipeline was started
+	PipelineBlockStart Type = "pipeline_block_start"
+	// PipelineBlockEnd means a block of Pipeline was finished
+	PipelineBlockEnd Type = "pipeline_block_end"
+	// PipelineStart means a Pipeline was started
+	PipelineStart Type = "pipeline_start"
+	// PipelineEnd means a Pipeline was finished
+	PipelineEnd Type = "pipeline_end"
+)
+
+// IsQueueEvent returns true if it's an event of the build queue
+func (t Type) IsQueueEvent() bool {
+	switch t {
+	case QueueEnter, QueueBuildable, QueueBlocked, QueueLeft, QueueTaskComplete:
+		return true
+	}
+	return false
+}
+
+// Subscription is the filter of events, all the properties need to be matched
+type Subscription map[string]string
+
+// JobSubscription subscribes all the job events, including the queue events
+func JobSubscription() Subscription {
+	return Subscription{"jenkins_channel": ChannelJob}
+}
+
+// PipelineSubscription subscribes all the Pipeline events
+func PipelineSubscription() Subscription {
+	return Subscription{"jenkins_channel": ChannelPipeline}
+}
+
+// QueueSubscriptions subscribes the events of the build queue only
+func QueueSubscriptions() []Subscription {
+	types := []Type{QueueEnter, QueueBuildable, QueueBlocked, QueueLeft, QueueTaskComplete}
+	subscriptions := make([]Subscription, len(types))
+	for i, t := range types {
+		subscriptions[i] = Subscription{"jenkins_channel": ChannelJob, "jenkins_event": string(t)}
+	}
+	return subscriptions
+}
+
+// Event is an event of Jenkins which comes from the SSE gateway
+type Event struct {
+	Channel        string `json:"jenkins_channel"`
+	Type           Type   `json:"jenkins_event"`
+	UUID           string `json:"jenkins_event_uuid"`
+	Organization   string `json:"jenkins_org"`
+	ObjectName     string `json:"jenkins_object_name"`
+	ObjectType     string `json:"jenkins_object_type"`
+	ObjectID       string `json:"jenkins_object_id"`
+	ObjectURL      string `json:"jenkins_object_url"`
+	JobName        string `json:"job_name"`
+	JobRunQueueID  string `json:"job_run_queueId"`
+	JobRunStatus   string `json:"job_run_status"`
+	JobMultiBranch string `json:"job_ismultibranch"`
+
+	PipelineJobName       string `json:"pipeline_job_name"`
+	PipelineRunID         string `json:"pipeline_run_id"`
+	PipelineStepFlowNode  string `json:"pipeline_step_flownode_id"`
+	PipelineStepStageName string `json:"pipeline_step_stage_name"`
+	PipelineStepName      string `json:"pipeline_step_name"`
+	PipelineStepIsPaused  string `json:"pipeline_step_is_paused"`
+
+	// Raw is the original data of the event, it has all the properties
+	Raw json.RawMessage `json:"-"`
+}
@@ -0,0 +1,223 @@
+package job
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const timeInQueueActionClass = "jenkins.metrics.impl.TimeInQueueAction"
+
+// BuildStats is the statistics of the build history of a job
+type BuildStats struct {
+	// Total is the number of all builds, including the running ones
+	Total int
+	// Completed is the number of the builds which have a result
+	Completed int
+	Succeeded int
+	Unstable  int
+	Failed    int
+	Aborted   int
+	// SuccessRate is the rate of the successful builds in the completed ones, from 0 to 1
+	SuccessRate float64
+
+	MeanDuration time.Duration
+	P50Duration  time.Duration
+	P90Duration  time.Duration
+	P95Duration  time.Duration
+	MaxDuration  time.Duration
+
+	// the queue wait times come from the metrics plugin, they are zero without it
+	MeanQueueWait time.Duration
+	MaxQueueWait  time.Duration
+
+	// LongestFailureStreak is the max number of the consecutive failed builds
+	LongestFailureStreak int
+	// CurrentFailureStreak is the number of the consecutive failed builds until the last completed one
+	CurrentFailureStreak int
+	// MTTR is the mean time from the first failed build to the next successful one
+	MTTR time.Duration
+}
+
+// GetBuildStats returns the statistics of the build history of a job
+func (q *Client) GetBuildStats(jobName string) (stats *BuildStats, err error) {
+	var builds []*Build
+	if builds, err = q.GetHistory(jobName); err == nil {
+		stats = AnalyzeBuilds(builds)
+	}
+	return
+}
+
+// AnalyzeBuilds returns the statistics of builds, the order of builds does not matter
+func AnalyzeBuilds(builds []*Build) *BuildStats {
+	sorted := make([]*Build, 0, len(builds))
+	for _, build := range builds {
+		if build != nil {
+			sorted = append(sorted, build)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Number < sorted[j].Number
+	})
+
+	stats := &BuildStats{Total: len(sorted)}
+	var (
+		durations    []time.Duration
+		queueWaits   []time.Duration
+		recoveries   []time.Duration
+		streak       int
+		brokenSince  time.Time
+		failureFound bool
+	)
+	for _, build := range sorted {
+		if build.Building || !build.Result.IsCompleted() {
+			continue
+		}
+		stats.Completed++
+		duration := time.Duration(build.Duration) * time.Millisecond
+		durations = append(durations, duration)
+		if wait, ok := build.QueueWait(); ok {
+			queueWaits = append(queueWaits, wait)
+		}
+
+		switch build.Result {
+		case BuildResultSuccess:
+			stats.Succeeded++
+		case BuildResultUnstable:
+			stats.Unstable++
+		case BuildResultFailure:
+			stats.Failed++
+		case BuildResultAborted:
+			stats.Aborted++
+		}
+
+		endTime := build.Timestamp.Add(duration)
+		switch build.Result {
+		case BuildResultFailure:
+			if !failureFound {
+				failureFound = true
+				brokenSince = endTime
+			}
+			streak++
+			if streak > stats.LongestFailureStreak {
+				stats.LongestFailureStreak = streak
+			}
+		case BuildResultSuccess:
+			if failureFound && !build.Timestamp.IsZero() && !brokenSince.IsZero() {
+				recoveries = append(recoveries, endTime.Sub(brokenSince))
+			}
+			failureFound = false
+			streak = 0
+		default:
+			streak = 0
+		}
+	}
+	stats.CurrentFailureStreak = streak
+
+	if stats.Completed > 0 {
+		stats.SuccessRate = float64(stats.Succeeded) / float64(stats.Completed)
+	}
+	stats.MeanDuration, stats.MaxDuration = meanAndMax(durations)
+	stats.P50Duration = percentile(durations, 50)
+	stats.P90Duration = percentile(durations, 90)
+	stats.P95Duration = percentile(durations, 95)
+	stats.MeanQueueWait, stats.MaxQueueWait = meanAndMax(queueWaits)
+	stats.MTTR, _ = meanAndMax(recoveries)
+	return stats
+}
+
+// QueueWait returns the time which the build spent in the queue, it's only available with the metrics plugin
+func (b *Build) QueueWait() (wait time.Duration, ok bool) {
+	for _, action := range b.Actions {
+		if action.Class == timeInQueueActionClass {
+			return time.Duration(action.QueuingDurationMillis) * time.Millisecond, true
+		}
+	}
+	return
+}
+
+func meanAndMax(durations []time.Duration) (mean, max time.Duration) {
+	if len(durations) == 0 {
+		return
+	}
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+		if duration > max {
+			max = duration
+		}
+	}
+	mean = total / time.Duration(len(durations))
+	return
+}
+
+// percentile returns the value of nearest-rank percentile
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// metrics returns the name and value of all the statistics
+func (s *BuildStats) metrics() [][2]string {
+	return [][2]string{
+		{"total", strconv.Itoa(s.Total)},
+		{"completed", strconv.Itoa(s.Completed)},
+		{"succeeded", strconv.Itoa(s.Succeeded)},
+		{"unstable", strconv.Itoa(s.Unstable)},
+		{"failed", strconv.Itoa(s.Failed)},
+		{"aborted", strconv.Itoa(s.Aborted)},
+		{"successRate", fmt.Sprintf("%.2f", s.SuccessRate)},
+		{"meanDuration", s.MeanDuration.String()},
+		{"p50Duration", s.P50Duration.String()},
+		{"p90Duration", s.P90Duration.String()},
+		{"p95Duration", s.P95Duration.String()},
+		{"maxDuration", s.MaxDuration.String()},
+		{"meanQueueWait", s.MeanQueueWait.String()},
+		{"maxQueueWait", s.MaxQueueWait.String()},
+		{"longestFailureStreak", strconv.Itoa(s.LongestFailureStreak)},
+		{"currentFailureStreak", strconv.Itoa(s.CurrentFailureStreak)},
+		{"mttr", s.MTTR.String()},
+	}
+}
+
+// WriteText writes the statistics as an aligned table
+func (s *BuildStats) WriteText(writer io.Writer) (err error) {
+	w := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+	for _, metric := range s.metrics() {
+		if _, err = fmt.Fprintf(w, "%s\t%s\n", metric[0], metric[1]); err != nil {
+			return
+		}
+	}
+	return w.Flush()
+}
+
+// WriteCSV writes the statistics as CSV which has the metric and value columns
+func (s *BuildStats) WriteCSV(writer io.Writer) (err error) {
+	w := csv.NewWriter(writer)
+	if err = w.Write([]string{"metric", "value"}); err != nil {
+		return
+	}
+	for _, metric := range s.metrics() {
+		if err = w.Write([]string{metric[0], metric[1]}); err != nil {
+			return
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,89 @@
+package job
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/util"
+)
+
+func TestAnalyzeBuilds(t *testing.T) {
+	start := time.Date(2021, 8, 23, 0, 0, 0, 0, time.UTC)
+	newBuild := func(number int, result BuildResult, minutes int) *Build {
+		build := &Build{
+			Result:    result,
+			Duration:  int64(time.Duration(minutes) * time.Minute / time.Millisecond),
+			Timestamp: util.NewTime(start.Add(time.Duration(number) * time.Hour)),
+		}
+		build.Number = number
+		return build
+	}
+
+	queued := newBuild(1, BuildResultSuccess, 1)
+	queued.Actions = []BuildAction{{}, {Class: timeInQueueActionClass, QueuingDurationMillis: 30000}}
+	running := newBuild(8, "", 0)
+	running.Building = true
+
+	stats := AnalyzeBuilds([]*Build{
+		newBuild(7, BuildResultFailure, 4),
+		newBuild(6, BuildResultAborted, 1),
+		newBuild(5, BuildResultSuccess, 2),
+		newBuild(4, BuildResultFailure, 3),
+		newBuild(3, BuildResultFailure, 5),
+		newBuild(2, BuildResultUnstable, 2),
+		queued,
+		running,
+		nil,
+	})
+
+	if stats.Total != 8 || stats.Completed != 7 {
+		t.Errorf("got total %d and completed %d, want 8 and 7", stats.Total, stats.Completed)
+	}
+	if stats.Succeeded != 2 || stats.Failed != 3 || stats.Unstable != 1 || stats.Aborted != 1 {
+		t.Errorf("unexpected result counts: %+v", stats)
+	}
+	if want := 2.0 / 7; stats.SuccessRate != want {
+		t.Errorf("SuccessRate = %v, want %v", stats.SuccessRate, want)
+	}
+	if stats.MeanDuration != 18*time.Minute/7 {
+		t.Errorf("MeanDuration = %v, want %v", stats.MeanDuration, 18*time.Minute/7)
+	}
+	if stats.P50Duration != 2*time.Minute || stats.P90Duration != 5*time.Minute || stats.MaxDuration != 5*time.Minute {
+		t.Errorf("unexpected durations: p50 %v, p90 %v, max %v", stats.P50Duration, stats.P90Duration, stats.MaxDuration)
+	}
+	if stats.MeanQueueWait != 30*time.Second || stats.MaxQueueWait != 30*time.Second {
+		t.Errorf("unexpected queue wait: mean %v, max %v", stats.MeanQueueWait, stats.MaxQueueWait)
+	}
+	if stats.LongestFailureStreak != 2 || stats.CurrentFailureStreak != 1 {
+		t.Errorf("got failure streaks %d and %d, want 2 and 1", stats.LongestFailureStreak, stats.CurrentFailureStreak)
+	}
+	// broken since build 3 was finished, fixed when build 5 was finished
+	if want := 2*time.Hour - 3*time.Minute; stats.MTTR != want {
+		t.Errorf("MTTR = %v, want %v", stats.MTTR, want)
+	}
+}
+
+func TestBuildStatsRender(t *testing.T) {
+	stats := AnalyzeBuilds(nil)
+	if stats.Total != 0 || stats.SuccessRate != 0 {
+		t.Errorf("unexpected stats of empty builds: %+v", stats)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := stats.WriteCSV(buf); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "metric,value\ntotal,0\n") {
+		t.Errorf("unexpected CSV: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := stats.WriteText(buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "successRate           0.00\n") {
+		t.Errorf("unexpected text: %s", buf.String())
+	}
+}
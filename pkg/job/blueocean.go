@@ -1,9 +1,16 @@
 package job
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // BlueOceanClient is client for operating pipelines via BlueOcean RESTful API.
@@ -35,6 +42,88 @@ func (boClient *BlueOceanClient) Build(organization string, pipelines ...string)
 	return &pb, nil
 }
 
+// BuildWithParameters builds a pipeline with parameters for specific organization and pipelines.
+func (boClient *BlueOceanClient) BuildWithParameters(organization string, parameters []PipelineParameter, pipelines ...string) (*PipelineBuild, error) {
+	api := fmt.Sprintf("%s/runs/", getPipelineAPI(organization, pipelines...))
+	payload, err := json.Marshal(map[string][]PipelineParameter{"parameters": parameters})
+	if err != nil {
+		return nil, err
+	}
+	var pb PipelineBuild
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	err = boClient.RequestWithData(http.MethodPost, api, headers, bytes.NewReader(payload), 200, &pb)
+	if err != nil {
+		return nil, err
+	}
+	return &pb, nil
+}
+
+// Stop stops a Pipeline run, it waits until the run is stopped or timeout if blocking is true.
+func (boClient *BlueOceanClient) Stop(organization, runID string, blocking bool, timeoutInSecs int, pipelines ...string) (*PipelineBuild, error) {
+	api := fmt.Sprintf("%s/stop/?blocking=%t&timeOutInSecs=%d", getRunAPI(organization, runID, pipelines...), blocking, timeoutInSecs)
+	var pb PipelineBuild
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	err := boClient.RequestWithData(http.MethodPut, api, headers, nil, 200, &pb)
+	if err != nil {
+		return nil, err
+	}
+	return &pb, nil
+}
+
+// Replay replays a Pipeline run, returns the new queued run.
+func (boClient *BlueOceanClient) Replay(organization, runID string, pipelines ...string) (*PipelineBuild, error) {
+	api := fmt.Sprintf("%s/replay/", getRunAPI(organization, runID, pipelines...))
+	var pb PipelineBuild
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	err := boClient.RequestWithData(http.MethodPost, api, headers, nil, 200, &pb)
+	if err != nil {
+		return nil, err
+	}
+	return &pb, nil
+}
+
+// SubmitInput proceeds the pending input of a step with parameters.
+func (boClient *BlueOceanClient) SubmitInput(organization, runID, nodeID, stepID, inputID string,
+	parameters []PipelineParameter, pipelines ...string) error {
+	return boClient.submitInput(organization, runID, nodeID, stepID, inputRequest{
+		ID:         inputID,
+		Parameters: parameters,
+	}, pipelines...)
+}
+
+// AbortInput aborts the pending input of a step.
+func (boClient *BlueOceanClient) AbortInput(organization, runID, nodeID, stepID, inputID string, pipelines ...string) error {
+	return boClient.submitInput(organization, runID, nodeID, stepID, inputRequest{
+		ID:    inputID,
+		Abort: true,
+	}, pipelines...)
+}
+
+type inputRequest struct {
+	ID         string              `json:"id"`
+	Abort      bool                `json:"abort,omitempty"`
+	Parameters []PipelineParameter `json:"parameters,omitempty"`
+}
+
+func (boClient *BlueOceanClient) submitInput(organization, runID, nodeID, stepID string, request inputRequest, pipelines ...string) error {
+	api := fmt.Sprintf("%s/nodes/%s/steps/%s/", getRunAPI(organization, runID, pipelines...), nodeID, stepID)
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	_, err = boClient.RequestWithoutData(http.MethodPost, api, headers, bytes.NewReader(payload), 200)
+	return err
+}
+
 // GetBuild gets build result for specific organization, run ID and pipelines.
 func (boClient *BlueOceanClient) GetBuild(organization string, runID string, pipelines ...string) (*PipelineBuild, error) {
 	api := fmt.Sprintf("/blue/rest/organizations/%s/%s/runs/%s/", organization, ParsePipelinePath(pipelines...), runID)
@@ -49,27 +138,344 @@ func (boClient *BlueOceanClient) GetBuild(organization string, runID string, pip
 	return &pb, nil
 }
 
+// GetNodes gets the nodes, such as stages and parallel branches, of a Pipeline run.
+func (boClient *BlueOceanClient) GetNodes(organization, runID string, pipelines ...string) ([]PipelineNode, error) {
+	api := fmt.Sprintf("%s/nodes/", getRunAPI(organization, runID, pipelines...))
+	var nodes []PipelineNode
+	err := boClient.RequestWithData(http.MethodGet, api, nil, nil, 200, &nodes)
+	if err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// GetSteps gets the steps of a node of a Pipeline run.
+func (boClient *BlueOceanClient) GetSteps(organization, runID, nodeID string, pipelines ...string) ([]PipelineStep, error) {
+	api := fmt.Sprintf("%s/nodes/%s/steps/", getRunAPI(organization, runID, pipelines...), nodeID)
+	var steps []PipelineStep
+	err := boClient.RequestWithData(http.MethodGet, api, nil, nil, 200, &steps)
+	if err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// GetRunLog gets the log of a Pipeline run from the start offset.
+func (boClient *BlueOceanClient) GetRunLog(organization, runID string, start int64, pipelines ...string) (Log, error) {
+	api := fmt.Sprintf("%s/log/?start=%d", getRunAPI(organization, runID, pipelines...), start)
+	return boClient.getLog(api)
+}
+
+// GetNodeLog gets the log of a node of a Pipeline run from the start offset.
+func (boClient *BlueOceanClient) GetNodeLog(organization, runID, nodeID string, start int64, pipelines ...string) (Log, error) {
+	api := fmt.Sprintf("%s/nodes/%s/log/?start=%d", getRunAPI(organization, runID, pipelines...), nodeID, start)
+	return boClient.getLog(api)
+}
+
+// GetStepLog gets the log of a step of a Pipeline run from the start offset.
+func (boClient *BlueOceanClient) GetStepLog(organization, runID, nodeID, stepID string, start int64, pipelines ...string) (Log, error) {
+	api := fmt.Sprintf("%s/nodes/%s/steps/%s/log/?start=%d", getRunAPI(organization, runID, pipelines...), nodeID, stepID, start)
+	return boClient.getLog(api)
+}
+
+// GetBranches gets the branches of a multi-branch Pipeline.
+func (boClient *BlueOceanClient) GetBranches(organization string, start, limit int, pipelines ...string) ([]PipelineBranch, error) {
+	api := fmt.Sprintf("%s/branches/?start=%d&limit=%d", getPipelineAPI(organization, pipelines...), start, limit)
+	var branches []PipelineBranch
+	err := boClient.RequestWithData(http.MethodGet, api, nil, nil, 200, &branches)
+	if err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+// GetPullRequests gets the pull requests of a multi-branch Pipeline.
+func (boClient *BlueOceanClient) GetPullRequests(organization string, start, limit int, pipelines ...string) ([]PipelineBranch, error) {
+	api := fmt.Sprintf("%s/pullRequests/?start=%d&limit=%d", getPipelineAPI(organization, pipelines...), start, limit)
+	var pullRequests []PipelineBranch
+	err := boClient.RequestWithData(http.MethodGet, api, nil, nil, 200, &pullRequests)
+	if err != nil {
+		return nil, err
+	}
+	return pullRequests, nil
+}
+
+// GetBranch gets a branch of a multi-branch Pipeline, the branch is the job name of it. e.g. feature%2Fdemo
+func (boClient *BlueOceanClient) GetBranch(organization, branch string, pipelines ...string) (*PipelineBranch, error) {
+	api := fmt.Sprintf("%s/branches/%s/", getPipelineAPI(organization, pipelines...), url.PathEscape(branch))
+	var pipelineBranch PipelineBranch
+	err := boClient.RequestWithData(http.MethodGet, api, nil, nil, 200, &pipelineBranch)
+	if err != nil {
+		return nil, err
+	}
+	return &pipelineBranch, nil
+}
+
+// GetFavorites gets the favorites of a user.
+func (boClient *BlueOceanClient) GetFavorites(user string) ([]PipelineFavorite, error) {
+	api := fmt.Sprintf("/blue/rest/users/%s/favorites/", user)
+	var favorites []PipelineFavorite
+	err := boClient.RequestWithData(http.MethodGet, api, nil, nil, 200, &favorites)
+	if err != nil {
+		return nil, err
+	}
+	return favorites, nil
+}
+
+// SetFavorite marks or unmarks a Pipeline as a favorite of the current user.
+func (boClient *BlueOceanClient) SetFavorite(organization string, favorite bool, pipelines ...string) (*PipelineFavorite, error) {
+	api := fmt.Sprintf("%s/favorite/", getPipelineAPI(organization, pipelines...))
+	return boClient.setFavorite(api, favorite)
+}
+
+// SetBranchFavorite marks or unmarks a branch of a multi-branch Pipeline as a favorite of the current user.
+func (boClient *BlueOceanClient) SetBranchFavorite(organization, branch string, favorite bool, pipelines ...string) (*PipelineFavorite, error) {
+	api := fmt.Sprintf("%s/branches/%s/favorite/", getPipelineAPI(organization, pipelines...), url.PathEscape(branch))
+	return boClient.setFavorite(api, favorite)
+}
+
+func (boClient *BlueOceanClient) setFavorite(api string, favorite bool) (*PipelineFavorite, error) {
+	payload, err := json.Marshal(map[string]bool{"favorite": favorite})
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	var pipelineFavorite PipelineFavorite
+	err = boClient.RequestWithData(http.MethodPut, api, headers, bytes.NewReader(payload), 200, &pipelineFavorite)
+	if err != nil {
+		return nil, err
+	}
+	return &pipelineFavorite, nil
+}
+
+func (boClient *BlueOceanClient) getLog(api string) (Log, error) {
+	response, err := boClient.RequestWithResponse(http.MethodGet, api, nil, nil)
+	if err != nil {
+		return Log{}, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return Log{}, err
+	}
+	if response.StatusCode != 200 {
+		return Log{}, boClient.ErrorHandle(response.StatusCode, data)
+	}
+
+	log := Log{Text: string(data)}
+	log.HasMore = strings.ToLower(response.Header.Get("X-More-Data")) == "true"
+	log.NextStart, _ = strconv.ParseInt(response.Header.Get("X-Text-Size"), 10, 64)
+	return log, nil
+}
+
+func getPipelineAPI(organization string, pipelines ...string) string {
+	return fmt.Sprintf("/blue/rest/organizations/%s/%s", organization, ParsePipelinePath(pipelines...))
+}
+
+func getRunAPI(organization, runID string, pipelines ...string) string {
+	return fmt.Sprintf("%s/runs/%s", getPipelineAPI(organization, pipelines...), runID)
+}
+
+// PipelineParameter represents a parameter of a Pipeline run or an input.
+type PipelineParameter struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// PipelineState is the state of a Pipeline run, node or step.
+type PipelineState string
+
+const (
+	// PipelineStateQueued means it is waiting in the queue
+	PipelineStateQueued PipelineState = "QUEUED"
+	// PipelineStateRunning means it is running
+	PipelineStateRunning PipelineState = "RUNNING"
+	// PipelineStatePaused means it is waiting for an input
+	PipelineStatePaused PipelineState = "PAUSED"
+	// PipelineStateSkipped means it was skipped
+	PipelineStateSkipped PipelineState = "SKIPPED"
+	// PipelineStateNotBuilt means it was not built
+	PipelineStateNotBuilt PipelineState = "NOT_BUILT"
+	// PipelineStateFinished means it was finished
+	PipelineStateFinished PipelineState = "FINISHED"
+)
+
+// BuildResultUnknown is the result of BlueOcean which means it is not finished yet
+const BuildResultUnknown BuildResult = "UNKNOWN"
+
+// PipelineNode represents a node, such as a stage or a parallel branch, of a Pipeline run.
+type PipelineNode struct {
+	ID                 string         `json:"id,omitempty" description:"id"`
+	DisplayName        string         `json:"displayName,omitempty" description:"display name"`
+	DisplayDescription string         `json:"displayDescription,omitempty" description:"display description"`
+	Type               string         `json:"type,omitempty" description:"type. e.g. STAGE, PARALLEL"`
+	State              PipelineState  `json:"state,omitempty" description:"state. e.g. RUNNING"`
+	Result             BuildResult    `json:"result,omitempty" description:"result. e.g. SUCCESS"`
+	StartTime          Time           `json:"startTime,omitempty" description:"the time of start"`
+	DurationInMillis   int64          `json:"durationInMillis,omitempty" description:"duration time in millis"`
+	CauseOfBlockage    string         `json:"causeOfBlockage,omitempty" description:"the cause of blockage"`
+	FirstParent        string         `json:"firstParent,omitempty" description:"the id of the first parent node"`
+	Restartable        bool           `json:"restartable,omitempty" description:"restartable or not"`
+	Edges              []PipelineEdge `json:"edges,omitempty" description:"the edges to the next nodes"`
+}
+
+// Duration returns the duration of the node.
+func (n *PipelineNode) Duration() time.Duration {
+	return time.Duration(n.DurationInMillis) * time.Millisecond
+}
+
+// PipelineEdge represents an edge between two nodes of a Pipeline run.
+type PipelineEdge struct {
+	ID   string `json:"id,omitempty" description:"the id of the target node"`
+	Type string `json:"type,omitempty" description:"the type of the target node"`
+}
+
+// PipelineStep represents a step of a Pipeline run.
+type PipelineStep struct {
+	ID                 string         `json:"id,omitempty" description:"id"`
+	DisplayName        string         `json:"displayName,omitempty" description:"display name"`
+	DisplayDescription string         `json:"displayDescription,omitempty" description:"display description"`
+	Type               string         `json:"type,omitempty" description:"type. e.g. STEP"`
+	State              PipelineState  `json:"state,omitempty" description:"state. e.g. RUNNING"`
+	Result             BuildResult    `json:"result,omitempty" description:"result. e.g. SUCCESS"`
+	StartTime          Time           `json:"startTime,omitempty" description:"the time of start"`
+	DurationInMillis   int64          `json:"durationInMillis,omitempty" description:"duration time in millis"`
+	Input              *PipelineInput `json:"input,omitempty" description:"the pending input of the step"`
+}
+
+// Duration returns the duration of the step.
+func (s *PipelineStep) Duration() time.Duration {
+	return time.Duration(s.DurationInMillis) * time.Millisecond
+}
+
+// PipelineInput represents a pending input of a Pipeline step.
+type PipelineInput struct {
+	ID         string                `json:"id,omitempty" description:"id"`
+	Message    string                `json:"message,omitempty" description:"message"`
+	OK         string                `json:"ok,omitempty" description:"the text of the proceed button"`
+	Submitter  string                `json:"submitter,omitempty" description:"the users or groups who can submit"`
+	Parameters []ParameterDefinition `json:"parameters,omitempty" description:"the parameters of the input"`
+}
+
 // PipelineBuild represents a build detail of Pipeline.
 type PipelineBuild struct {
-	Actions                   []interface{} `json:"actions,omitempty" description:"the list of all actions"`
-	ArtifactsZipFile          interface{}   `json:"artifactsZipFile,omitempty" description:"the artifacts zip file"`
-	CauseOfBlockage           string        `json:"causeOfBlockage,omitempty" description:"the cause of blockage"`
-	Causes                    []interface{} `json:"causes,omitempty"`
-	ChangeSet                 []interface{} `json:"changeSet,omitempty" description:"changeset information"`
-	Description               interface{}   `json:"description,omitempty" description:"description"`
-	DurationInMillis          interface{}   `json:"durationInMillis,omitempty" description:"duration time in millis"`
-	EnQueueTime               Time          `json:"enQueueTime,omitempty" description:"the time of enter the queue"`
-	EndTime                   Time          `json:"endTime,omitempty" description:"the time of end"`
-	EstimatedDurationInMillis interface{}   `json:"estimatedDurationInMillis,omitempty" description:"estimated duration time in millis"`
-	ID                        string        `json:"id,omitempty" description:"id"`
-	Name                      interface{}   `json:"name,omitempty" description:"name"`
-	Organization              string        `json:"organization,omitempty" description:"the name of organization"`
-	Pipeline                  string        `json:"pipeline,omitempty" description:"pipeline"`
-	Replayable                bool          `json:"replayable,omitempty" description:"replayable or not"`
-	Result                    string        `json:"result,omitempty" description:"the result of pipeline run. e.g. SUCCESS"`
-	RunSummary                interface{}   `json:"runSummary,omitempty" description:"pipeline run summary"`
-	StartTime                 Time          `json:"startTime,omitempty" description:"the time of start"`
-	State                     string        `json:"state,omitempty" description:"run state. e.g. RUNNING"`
-	Type                      string        `json:"type,omitempty" description:"type"`
-	QueueID                   string        `json:"queueId,omitempty" description:"queue id"`
+	Actions                   []interface{}       `json:"actions,omitempty" description:"the list of all actions"`
+	ArtifactsZipFile          string              `json:"artifactsZipFile,omitempty" description:"the artifacts zip file"`
+	CauseOfBlockage           string              `json:"causeOfBlockage,omitempty" description:"the cause of blockage"`
+	Causes                    []PipelineCause     `json:"causes,omitempty"`
+	ChangeSet                 []PipelineChangeSet `json:"changeSet,omitempty" description:"changeset information"`
+	CommitID                  string              `json:"commitId,omitempty" description:"the commit id of a multi-branch Pipeline run"`
+	CommitURL                 string              `json:"commitUrl,omitempty" description:"the commit URL of a multi-branch Pipeline run"`
+	Description               string              `json:"description,omitempty" description:"description"`
+	DurationInMillis          int64               `json:"durationInMillis,omitempty" description:"duration time in millis"`
+	EnQueueTime               Time                `json:"enQueueTime,omitempty" description:"the time of enter the queue"`
+	EndTime                   Time                `json:"endTime,omitempty" description:"the time of end"`
+	EstimatedDurationInMillis int64               `json:"estimatedDurationInMillis,omitempty" description:"estimated duration time in millis"`
+	ID                        string              `json:"id,omitempty" description:"id"`
+	Name                      string              `json:"name,omitempty" description:"name"`
+	Organization              string              `json:"organization,omitempty" description:"the name of organization"`
+	Pipeline                  string              `json:"pipeline,omitempty" description:"pipeline"`
+	Replayable                bool                `json:"replayable,omitempty" description:"replayable or not"`
+	Result                    BuildResult         `json:"result,omitempty" description:"the result of pipeline run. e.g. SUCCESS"`
+	RunSummary                string              `json:"runSummary,omitempty" description:"pipeline run summary"`
+	StartTime                 Time                `json:"startTime,omitempty" description:"the time of start"`
+	State                     PipelineState       `json:"state,omitempty" description:"run state. e.g. RUNNING"`
+	Type                      string              `json:"type,omitempty" description:"type"`
+	QueueID                   string              `json:"queueId,omitempty" description:"queue id"`
+}
+
+// Duration returns the duration of the run.
+func (pb *PipelineBuild) Duration() time.Duration {
+	return time.Duration(pb.DurationInMillis) * time.Millisecond
+}
+
+// EstimatedDuration returns the estimated duration of the run, it's negative if there's no estimation.
+func (pb *PipelineBuild) EstimatedDuration() time.Duration {
+	return time.Duration(pb.EstimatedDurationInMillis) * time.Millisecond
+}
+
+// QueueDuration returns how long the run waited in the queue, it's zero if the run has not started.
+func (pb *PipelineBuild) QueueDuration() time.Duration {
+	if pb.EnQueueTime.IsZero() || pb.StartTime.IsZero() {
+		return 0
+	}
+	return pb.StartTime.Sub(pb.EnQueueTime.Time)
+}
+
+// Elapsed returns how long the run has taken until now, or the duration if it's finished.
+func (pb *PipelineBuild) Elapsed(now time.Time) time.Duration {
+	switch {
+	case pb.StartTime.IsZero():
+		return 0
+	case !pb.EndTime.IsZero():
+		return pb.EndTime.Sub(pb.StartTime.Time)
+	default:
+		return now.Sub(pb.StartTime.Time)
+	}
+}
+
+// PipelineCause represents a cause of a Pipeline run.
+type PipelineCause struct {
+	Class            string `json:"_class,omitempty" description:"the class of the cause"`
+	ShortDescription string `json:"shortDescription,omitempty" description:"short description"`
+	UserID           string `json:"userId,omitempty" description:"the id of the user who triggered the run"`
+	UserName         string `json:"userName,omitempty" description:"the name of the user who triggered the run"`
+}
+
+// PipelineChangeSet represents a change of a Pipeline run.
+type PipelineChangeSet struct {
+	AffectedPaths []string       `json:"affectedPaths,omitempty" description:"the affected paths"`
+	Author        PipelineAuthor `json:"author,omitempty" description:"the author of the change"`
+	CheckoutCount int            `json:"checkoutCount,omitempty" description:"the count of checkout"`
+	CommitID      string         `json:"commitId,omitempty" description:"commit id"`
+	Msg           string         `json:"msg,omitempty" description:"commit message"`
+	Timestamp     Time           `json:"timestamp,omitempty" description:"the time of commit"`
+	URL           string         `json:"url,omitempty" description:"the URL of the commit"`
+}
+
+// PipelineAuthor represents an author of a change.
+type PipelineAuthor struct {
+	ID       string `json:"id,omitempty" description:"id"`
+	FullName string `json:"fullName,omitempty" description:"full name"`
+	Email    string `json:"email,omitempty" description:"email"`
+	Avatar   string `json:"avatar,omitempty" description:"the URL of avatar"`
+}
+
+// PipelineBranch represents a branch or a pull request of a multi-branch Pipeline.
+type PipelineBranch struct {
+	Name                      string                `json:"name,omitempty" description:"name"`
+	DisplayName               string                `json:"displayName,omitempty" description:"display name"`
+	FullName                  string                `json:"fullName,omitempty" description:"full name"`
+	FullDisplayName           string                `json:"fullDisplayName,omitempty" description:"full display name"`
+	Organization              string                `json:"organization,omitempty" description:"the name of organization"`
+	Disabled                  bool                  `json:"disabled,omitempty" description:"disabled or not"`
+	WeatherScore              HealthScore           `json:"weatherScore,omitempty" description:"the health score"`
+	EstimatedDurationInMillis int64                 `json:"estimatedDurationInMillis,omitempty" description:"estimated duration time in millis"`
+	Parameters                []ParameterDefinition `json:"parameters,omitempty" description:"the parameters of the branch"`
+	LatestRun                 *PipelineBuild        `json:"latestRun,omitempty" description:"the latest run"`
+	Branch                    *BranchInfo           `json:"branch,omitempty" description:"the metadata of the branch"`
+	PullRequest               *PullRequestInfo      `json:"pullRequest,omitempty" description:"the metadata of the pull request"`
+}
+
+// BranchInfo represents the metadata of a branch.
+type BranchInfo struct {
+	IsPrimary bool   `json:"isPrimary,omitempty" description:"primary branch or not"`
+	URL       string `json:"url,omitempty" description:"the URL of the branch"`
+}
+
+// PullRequestInfo represents the metadata of a pull request.
+type PullRequestInfo struct {
+	ID     string `json:"id,omitempty" description:"id"`
+	Title  string `json:"title,omitempty" description:"title"`
+	URL    string `json:"url,omitempty" description:"the URL of the pull request"`
+	Author string `json:"author,omitempty" description:"the author of the pull request"`
+}
+
+// PipelineFavorite represents a favorite item of a user.
+type PipelineFavorite struct {
+	Item PipelineBranch `json:"item,omitempty" description:"the favorite Pipeline or branch"`
 }
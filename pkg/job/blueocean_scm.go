@@ -0,0 +1,220 @@
+package job
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// SCMGitHub is the id of GitHub SCM in BlueOcean
+	SCMGitHub = "github"
+	// SCMGitHubEnterprise is the id of GitHub Enterprise SCM in BlueOcean
+	SCMGitHubEnterprise = "github-enterprise"
+	// SCMBitbucketCloud is the id of Bitbucket Cloud SCM in BlueOcean
+	SCMBitbucketCloud = "bitbucket-cloud"
+	// SCMBitbucketServer is the id of Bitbucket Server SCM in BlueOcean
+	SCMBitbucketServer = "bitbucket-server"
+	// SCMGit is the id of generic Git SCM in BlueOcean
+	SCMGit = "git"
+
+	gitPipelineCreateRequestClass       = "io.jenkins.blueocean.blueocean_git_pipeline.GitPipelineCreateRequest"
+	githubPipelineCreateRequestClass    = "io.jenkins.blueocean.blueocean_github_pipeline.GithubPipelineCreateRequest"
+	bitbucketPipelineCreateRequestClass = "io.jenkins.blueocean.blueocean_bitbucket_pipeline.BitbucketPipelineCreateRequest"
+)
+
+// PipelineCreateRequest is the request for creating a Pipeline via BlueOcean.
+type PipelineCreateRequest struct {
+	Name      string    `json:"name"`
+	Class     string    `json:"$class"`
+	SCMConfig SCMConfig `json:"scmConfig"`
+}
+
+// SCMConfig is the SCM config of a Pipeline creating request.
+type SCMConfig struct {
+	ID           string                 `json:"id,omitempty" description:"the id of SCM. e.g. github"`
+	URI          string                 `json:"uri,omitempty" description:"the URI of the repository or the API"`
+	CredentialID string                 `json:"credentialId,omitempty" description:"the id of the credential"`
+	Config       map[string]interface{} `json:"config,omitempty" description:"the extra config of SCM"`
+}
+
+// NewGitPipelineCreateRequest returns a request for creating a Pipeline from a generic Git repository.
+func NewGitPipelineCreateRequest(name, repositoryURL, credentialID string) PipelineCreateRequest {
+	return PipelineCreateRequest{
+		Name:  name,
+		Class: gitPipelineCreateRequestClass,
+		SCMConfig: SCMConfig{
+			URI:          repositoryURL,
+			CredentialID: credentialID,
+		},
+	}
+}
+
+// NewGitHubPipelineCreateRequest returns a request for creating a Pipeline from GitHub repositories,
+// the apiURL is only required for GitHub Enterprise.
+func NewGitHubPipelineCreateRequest(scmID, apiURL, credentialID, owner string, repositories ...string) PipelineCreateRequest {
+	return PipelineCreateRequest{
+		Name:  owner,
+		Class: githubPipelineCreateRequestClass,
+		SCMConfig: SCMConfig{
+			ID:           scmID,
+			URI:          apiURL,
+			CredentialID: credentialID,
+			Config: map[string]interface{}{
+				"orgName": owner,
+				"repos":   repositories,
+			},
+		},
+	}
+}
+
+// NewBitbucketPipelineCreateRequest returns a request for creating a Pipeline from a Bitbucket repository.
+func NewBitbucketPipelineCreateRequest(scmID, apiURL, credentialID, owner, repository string) PipelineCreateRequest {
+	return PipelineCreateRequest{
+		Name:  repository,
+		Class: bitbucketPipelineCreateRequestClass,
+		SCMConfig: SCMConfig{
+			ID:           scmID,
+			URI:          apiURL,
+			CredentialID: credentialID,
+			Config: map[string]interface{}{
+				"repoOwner":  owner,
+				"repository": repository,
+			},
+		},
+	}
+}
+
+// CreatePipeline creates a Pipeline from SCM for specific organization.
+func (boClient *BlueOceanClient) CreatePipeline(organization string, request PipelineCreateRequest) (*BluePipeline, error) {
+	api := fmt.Sprintf("/blue/rest/organizations/%s/pipelines/", organization)
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	var pipeline BluePipeline
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	err = boClient.RequestWithData(http.MethodPost, api, headers, bytes.NewReader(payload), 201, &pipeline)
+	if err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// ValidateSCMCredential validates the credential of GitHub or Bitbucket, creates it if it's valid.
+// It returns the id of the credential.
+func (boClient *BlueOceanClient) ValidateSCMCredential(organization, scmID string, request SCMCredentialRequest) (string, error) {
+	api := fmt.Sprintf("/blue/rest/organizations/%s/scm/%s/validate/", organization, scmID)
+	if request.APIURL != "" {
+		api = fmt.Sprintf("%s?apiUrl=%s", api, url.QueryEscape(request.APIURL))
+	}
+	return boClient.validateCredential(http.MethodPut, api, request)
+}
+
+// ValidateGitCredential validates if the credential can access a generic Git repository.
+// It returns the id of the credential.
+func (boClient *BlueOceanClient) ValidateGitCredential(organization, repositoryURL, credentialID string) (string, error) {
+	api := fmt.Sprintf("/blue/rest/organizations/%s/scm/%s/validate/", organization, SCMGit)
+	return boClient.validateCredential(http.MethodPost, api, map[string]string{
+		"repositoryUrl": repositoryURL,
+		"credentialId":  credentialID,
+	})
+}
+
+func (boClient *BlueOceanClient) validateCredential(method, api string, request interface{}) (string, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	result := struct {
+		CredentialID string `json:"credentialId"`
+	}{}
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	err = boClient.RequestWithData(method, api, headers, bytes.NewReader(payload), 200, &result)
+	if err != nil {
+		return "", err
+	}
+	return result.CredentialID, nil
+}
+
+// GetSCMOrganizations gets the organizations of a SCM which the credential can access.
+func (boClient *BlueOceanClient) GetSCMOrganizations(organization, scmID, credentialID, apiURL string) ([]SCMOrganization, error) {
+	api := fmt.Sprintf("/blue/rest/organizations/%s/scm/%s/organizations/?credentialId=%s",
+		organization, scmID, url.QueryEscape(credentialID))
+	if apiURL != "" {
+		api = fmt.Sprintf("%s&apiUrl=%s", api, url.QueryEscape(apiURL))
+	}
+	var organizations []SCMOrganization
+	err := boClient.RequestWithData(http.MethodGet, api, nil, nil, 200, &organizations)
+	if err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+// GetSCMRepositories gets the repositories of a SCM organization, the page number starts from 1.
+func (boClient *BlueOceanClient) GetSCMRepositories(organization, scmID, credentialID, apiURL, owner string,
+	pageNumber, pageSize int) (*SCMRepositories, error) {
+	api := fmt.Sprintf("/blue/rest/organizations/%s/scm/%s/organizations/%s/repositories/?credentialId=%s&pageNumber=%d&pageSize=%d",
+		organization, scmID, owner, url.QueryEscape(credentialID), pageNumber, pageSize)
+	if apiURL != "" {
+		api = fmt.Sprintf("%s&apiUrl=%s", api, url.QueryEscape(apiURL))
+	}
+	result := struct {
+		Repositories SCMRepositories `json:"repositories"`
+	}{}
+	err := boClient.RequestWithData(http.MethodGet, api, nil, nil, 200, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Repositories, nil
+}
+
+// SCMCredentialRequest is the request for validating a credential of SCM.
+type SCMCredentialRequest struct {
+	AccessToken string `json:"accessToken,omitempty" description:"the access token of GitHub"`
+	UserName    string `json:"userName,omitempty" description:"the username of Bitbucket"`
+	Password    string `json:"password,omitempty" description:"the password of Bitbucket"`
+	APIURL      string `json:"-" description:"the API URL of GitHub Enterprise or Bitbucket Server"`
+}
+
+// BluePipeline represents a Pipeline of BlueOcean.
+type BluePipeline struct {
+	Class           string      `json:"_class,omitempty" description:"the class of the Pipeline"`
+	Name            string      `json:"name,omitempty" description:"name"`
+	DisplayName     string      `json:"displayName,omitempty" description:"display name"`
+	FullName        string      `json:"fullName,omitempty" description:"full name"`
+	FullDisplayName string      `json:"fullDisplayName,omitempty" description:"full display name"`
+	Organization    string      `json:"organization,omitempty" description:"the name of organization"`
+	WeatherScore    HealthScore `json:"weatherScore,omitempty" description:"the health score"`
+}
+
+// SCMOrganization represents an organization of SCM.
+type SCMOrganization struct {
+	Name                        string `json:"name,omitempty" description:"name"`
+	Avatar                      string `json:"avatar,omitempty" description:"the URL of avatar"`
+	JenkinsOrganizationPipeline bool   `json:"jenkinsOrganizationPipeline,omitempty" description:"there's an organization folder or not"`
+}
+
+// SCMRepositories represents a page of SCM repositories.
+type SCMRepositories struct {
+	Items    []SCMRepository `json:"items,omitempty" description:"the repositories"`
+	LastPage int             `json:"lastPage,omitempty" description:"the last page number"`
+	NextPage int             `json:"nextPage,omitempty" description:"the next page number"`
+	PageSize int             `json:"pageSize,omitempty" description:"the page size"`
+}
+
+// SCMRepository represents a repository of SCM.
+type SCMRepository struct {
+	Name          string `json:"name,omitempty" description:"name"`
+	FullName      string `json:"fullName,omitempty" description:"full name"`
+	Description   string `json:"description,omitempty" description:"description"`
+	DefaultBranch string `json:"defaultBranch,omitempty" description:"the default branch"`
+	Private       bool   `json:"private,omitempty" description:"private or not"`
+}
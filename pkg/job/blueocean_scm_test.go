@@ -0,0 +1,133 @@
+package job
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BlueOcean SCM test", func() {
+	var (
+		ctrl         *gomock.Controller
+		boClient     BlueOceanClient
+		roundTripper *mhttp.MockRoundTripper
+		organization string
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		roundTripper = mhttp.NewMockRoundTripper(ctrl)
+		boClient = BlueOceanClient{}
+		boClient.RoundTripper = roundTripper
+		boClient.URL = "http://localhost"
+		organization = "jenkins"
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	givenRequest := func(method, api, body string, statusCode int, responseBody string) {
+		request, _ := http.NewRequest(method, fmt.Sprintf("%s/blue/rest/organizations/%s/%s", boClient.URL, organization, api),
+			bytes.NewBufferString(body))
+		if body != "" {
+			request.Header.Set("Content-Type", "application/json")
+		}
+		if method == http.MethodPost {
+			core.PrepareCommonPost(request, responseBody, roundTripper, "", "", boClient.URL).StatusCode = statusCode
+			return
+		}
+		response := &http.Response{
+			StatusCode: statusCode,
+			Request:    request,
+			Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+		}
+		roundTripper.EXPECT().RoundTrip(core.NewVerboseRequestMatcher(request).WithBody().WithQuery()).Return(response, nil)
+	}
+
+	Context("CreatePipeline", func() {
+		It("from a Git repository", func() {
+			givenRequest(http.MethodPost, "pipelines/",
+				`{"name":"demo","$class":"io.jenkins.blueocean.blueocean_git_pipeline.GitPipelineCreateRequest","scmConfig":{"uri":"https://github.com/jenkins-zh/demo.git","credentialId":"git"}}`,
+				201, `{"name":"demo","fullName":"demo","organization":"jenkins"}`)
+			pipeline, err := boClient.CreatePipeline(organization,
+				NewGitPipelineCreateRequest("demo", "https://github.com/jenkins-zh/demo.git", "git"))
+			Expect(err).Should(BeNil())
+			Expect(pipeline.FullName).Should(Equal("demo"))
+		})
+		It("from GitHub repositories", func() {
+			givenRequest(http.MethodPost, "pipelines/",
+				`{"name":"jenkins-zh","$class":"io.jenkins.blueocean.blueocean_github_pipeline.GithubPipelineCreateRequest","scmConfig":{"id":"github","credentialId":"github","config":{"orgName":"jenkins-zh","repos":["demo"]}}}`,
+				201, `{"name":"jenkins-zh"}`)
+			pipeline, err := boClient.CreatePipeline(organization,
+				NewGitHubPipelineCreateRequest(SCMGitHub, "", "github", "jenkins-zh", "demo"))
+			Expect(err).Should(BeNil())
+			Expect(pipeline.Name).Should(Equal("jenkins-zh"))
+		})
+		It("from a Bitbucket repository", func() {
+			givenRequest(http.MethodPost, "pipelines/",
+				`{"name":"demo","$class":"io.jenkins.blueocean.blueocean_bitbucket_pipeline.BitbucketPipelineCreateRequest","scmConfig":{"id":"bitbucket-server","uri":"https://bitbucket.com","credentialId":"bitbucket","config":{"repoOwner":"PROJ","repository":"demo"}}}`,
+				201, `{"name":"demo"}`)
+			pipeline, err := boClient.CreatePipeline(organization,
+				NewBitbucketPipelineCreateRequest(SCMBitbucketServer, "https://bitbucket.com", "bitbucket", "PROJ", "demo"))
+			Expect(err).Should(BeNil())
+			Expect(pipeline.Name).Should(Equal("demo"))
+		})
+		It("with an unexpected status code", func() {
+			givenRequest(http.MethodPost, "pipelines/",
+				`{"name":"demo","$class":"io.jenkins.blueocean.blueocean_git_pipeline.GitPipelineCreateRequest","scmConfig":{"uri":"fake"}}`,
+				400, `{"message":"invalid uri"}`)
+			_, err := boClient.CreatePipeline(organization, NewGitPipelineCreateRequest("demo", "fake", ""))
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Validate credential", func() {
+		It("GitHub Enterprise", func() {
+			givenRequest(http.MethodPut, "scm/github-enterprise/validate/?apiUrl=https%3A%2F%2Fgithub.com%2Fapi%2Fv3",
+				`{"accessToken":"token"}`, 200, `{"credentialId":"github-enterprise"}`)
+			credentialID, err := boClient.ValidateSCMCredential(organization, SCMGitHubEnterprise, SCMCredentialRequest{
+				AccessToken: "token",
+				APIURL:      "https://github.com/api/v3",
+			})
+			Expect(err).Should(BeNil())
+			Expect(credentialID).Should(Equal("github-enterprise"))
+		})
+		It("Git", func() {
+			givenRequest(http.MethodPost, "scm/git/validate/",
+				`{"credentialId":"git","repositoryUrl":"https://github.com/jenkins-zh/demo.git"}`, 200, `{"credentialId":"git"}`)
+			credentialID, err := boClient.ValidateGitCredential(organization, "https://github.com/jenkins-zh/demo.git", "git")
+			Expect(err).Should(BeNil())
+			Expect(credentialID).Should(Equal("git"))
+		})
+	})
+
+	Context("List SCM resources", func() {
+		It("GetSCMOrganizations", func() {
+			givenRequest(http.MethodGet, "scm/github/organizations/?credentialId=github", "", 200,
+				`[{"name":"jenkins-zh","avatar":"https://avatars.githubusercontent.com/u/1","jenkinsOrganizationPipeline":true}]`)
+			organizations, err := boClient.GetSCMOrganizations(organization, SCMGitHub, "github", "")
+			Expect(err).Should(BeNil())
+			Expect(organizations).Should(HaveLen(1))
+			Expect(organizations[0].JenkinsOrganizationPipeline).Should(BeTrue())
+		})
+		It("GetSCMRepositories", func() {
+			givenRequest(http.MethodGet,
+				"scm/github/organizations/jenkins-zh/repositories/?credentialId=github&pageNumber=1&pageSize=2", "", 200,
+				`{"repositories":{"items":[{"name":"demo","fullName":"jenkins-zh/demo","defaultBranch":"master","private":true}],"nextPage":2,"pageSize":2}}`)
+			repositories, err := boClient.GetSCMRepositories(organization, SCMGitHub, "github", "", "jenkins-zh", 1, 2)
+			Expect(err).Should(BeNil())
+			Expect(repositories.NextPage).Should(Equal(2))
+			Expect(repositories.Items).Should(HaveLen(1))
+			Expect(repositories.Items[0].Private).Should(BeTrue())
+			Expect(repositories.Items[0].DefaultBranch).Should(Equal("master"))
+		})
+	})
+})
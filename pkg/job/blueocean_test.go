@@ -9,6 +9,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -169,6 +170,63 @@ var _ = Describe("Pipeline test via BlueOcean RESTful API", func() {
 			Expect(err).Should(BeNil())
 			Expect(pipelineBuild).ShouldNot(BeNil())
 		})
+		It("Get a finished Pipeline run with typed fields", func() {
+			const (
+				pipelineName = "fakePipeline"
+				runID        = "5"
+			)
+			given(pipelineName, runID, 200, `
+{
+  "_class": "io.jenkins.blueocean.rest.impl.pipeline.PipelineRunImpl",
+  "_links": {"self": {"href": "/blue/rest/organizations/jenkins/pipelines/fakePipeline/runs/5/"}},
+  "artifactsZipFile": "/job/fakePipeline/5/artifact/*zip*/archive.zip",
+  "causes": [{"_class": "hudson.model.Cause$UserIdCause", "shortDescription": "Started by user admin", "userId": "admin", "userName": "admin"}],
+  "changeSet": [{
+    "_class": "io.jenkins.blueocean.service.embedded.rest.ChangeSetResource",
+    "affectedPaths": ["Jenkinsfile"],
+    "author": {"id": "fake", "fullName": "Fake", "email": "fake@example.com"},
+    "commitId": "abc",
+    "msg": "fix",
+    "timestamp": "2021-08-25T07:20:13.000+0000",
+    "url": "https://github.com/fake/fake/commit/abc"
+  }],
+  "description": null,
+  "durationInMillis": 2000,
+  "enQueueTime": "2021-08-25T07:29:13.000+0000",
+  "startTime": "2021-08-25T07:29:14.000+0000",
+  "endTime": "2021-08-25T07:29:16.000+0000",
+  "estimatedDurationInMillis": -1,
+  "id": "5",
+  "name": "release",
+  "result": "SUCCESS",
+  "runSummary": "stable",
+  "state": "FINISHED",
+  "newFieldFromFuture": {"anything": true}
+}`)
+			pipelineBuild, err := boClient.GetBuild(organization, runID, pipelineName)
+			Expect(err).Should(BeNil())
+			Expect(pipelineBuild.ArtifactsZipFile).Should(Equal("/job/fakePipeline/5/artifact/*zip*/archive.zip"))
+			Expect(pipelineBuild.Causes).Should(HaveLen(1))
+			Expect(pipelineBuild.Causes[0].UserID).Should(Equal("admin"))
+			Expect(pipelineBuild.ChangeSet).Should(HaveLen(1))
+			Expect(pipelineBuild.ChangeSet[0].Author.FullName).Should(Equal("Fake"))
+			Expect(pipelineBuild.ChangeSet[0].Timestamp.IsZero()).Should(BeFalse())
+			Expect(pipelineBuild.Description).Should(BeEmpty())
+			Expect(pipelineBuild.Name).Should(Equal("release"))
+			Expect(pipelineBuild.RunSummary).Should(Equal("stable"))
+			Expect(pipelineBuild.State).Should(Equal(PipelineStateFinished))
+			Expect(pipelineBuild.Duration()).Should(Equal(2 * time.Second))
+			Expect(pipelineBuild.EstimatedDuration() < 0).Should(BeTrue())
+			Expect(pipelineBuild.QueueDuration()).Should(Equal(time.Second))
+			Expect(pipelineBuild.Elapsed(time.Now())).Should(Equal(2 * time.Second))
+		})
+		It("Get the elapsed time of a running Pipeline run", func() {
+			start := time.Now().Add(-time.Minute)
+			pipelineBuild := PipelineBuild{StartTime: Time{Time: start}}
+			Expect(pipelineBuild.Elapsed(start.Add(time.Minute))).Should(Equal(time.Minute))
+			Expect(pipelineBuild.QueueDuration()).Should(BeZero())
+			Expect((&PipelineBuild{}).Elapsed(time.Now())).Should(BeZero())
+		})
 		It("Get specific Pipeline run with an error", func() {
 			const (
 				organization = "jenkins"
@@ -189,4 +247,241 @@ var _ = Describe("Pipeline test via BlueOcean RESTful API", func() {
 			Expect(err).ShouldNot(BeNil())
 		})
 	})
+
+	Context("GetNodes", func() {
+		given := func(statusCode int, givenResponseJSON string) {
+			request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/blue/rest/organizations/%s/pipelines/folder/pipelines/fakePipeline/runs/1/nodes/", boClient.URL, organization), nil)
+			response := &http.Response{
+				StatusCode: statusCode,
+				Request:    request,
+				Body:       io.NopCloser(bytes.NewBufferString(givenResponseJSON)),
+			}
+			roundTripper.EXPECT().RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+		}
+		It("Get nodes of a nested Pipeline run", func() {
+			given(200, `
+[{
+  "displayName": "Build",
+  "durationInMillis": 1500,
+  "edges": [{"id": "12", "type": "PARALLEL"}, {"id": "13", "type": "PARALLEL"}],
+  "firstParent": null,
+  "id": "6",
+  "result": "SUCCESS",
+  "startTime": "2021-08-25T07:29:13.499+0000",
+  "state": "FINISHED",
+  "type": "STAGE"
+}]`)
+			nodes, err := boClient.GetNodes(organization, "1", "folder", "fakePipeline")
+			Expect(err).Should(BeNil())
+			Expect(nodes).Should(HaveLen(1))
+			Expect(nodes[0].State).Should(Equal(PipelineStateFinished))
+			Expect(nodes[0].Result).Should(Equal(BuildResultSuccess))
+			Expect(nodes[0].Duration().Seconds()).Should(Equal(1.5))
+			Expect(nodes[0].Edges).Should(HaveLen(2))
+			Expect(nodes[0].StartTime.IsZero()).Should(BeFalse())
+		})
+		It("Get nodes with an error", func() {
+			given(500, "")
+			_, err := boClient.GetNodes(organization, "1", "folder", "fakePipeline")
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+
+	Context("GetSteps", func() {
+		It("Get steps of a node", func() {
+			request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/blue/rest/organizations/%s/pipelines/fakePipeline/runs/1/nodes/6/steps/", boClient.URL, organization), nil)
+			response := &http.Response{
+				StatusCode: 200,
+				Request:    request,
+				Body: io.NopCloser(bytes.NewBufferString(`
+[{
+  "displayName": "Shell Script",
+  "durationInMillis": 20,
+  "id": "7",
+  "result": "UNKNOWN",
+  "state": "PAUSED",
+  "type": "STEP",
+  "input": {"id": "Fake", "message": "Deploy?", "ok": "Yes", "parameters": [{"name": "env", "type": "StringParameterDefinition"}]}
+}]`)),
+			}
+			roundTripper.EXPECT().RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+
+			steps, err := boClient.GetSteps(organization, "1", "6", "fakePipeline")
+			Expect(err).Should(BeNil())
+			Expect(steps).Should(HaveLen(1))
+			Expect(steps[0].State).Should(Equal(PipelineStatePaused))
+			Expect(steps[0].Result).Should(Equal(BuildResultUnknown))
+			Expect(steps[0].Input).ShouldNot(BeNil())
+			Expect(steps[0].Input.OK).Should(Equal("Yes"))
+			Expect(steps[0].Input.Parameters[0].Name).Should(Equal("env"))
+		})
+	})
+
+	Context("Logs", func() {
+		given := func(api string, statusCode int) {
+			request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/blue/rest/organizations/%s/pipelines/fakePipeline/runs/1/%s", boClient.URL, organization, api), nil)
+			response := &http.Response{
+				StatusCode: statusCode,
+				Request:    request,
+				Header: map[string][]string{
+					"X-More-Data": {"true"},
+					"X-Text-Size": {"8"},
+				},
+				Body: io.NopCloser(bytes.NewBufferString("fake log")),
+			}
+			roundTripper.EXPECT().RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+		}
+		It("Get the log of a run", func() {
+			given("log/", 200)
+			log, err := boClient.GetRunLog(organization, "1", 0, "fakePipeline")
+			Expect(err).Should(BeNil())
+			Expect(log.Text).Should(Equal("fake log"))
+		})
+		It("Get the log of a node", func() {
+			given("nodes/6/log/", 200)
+			log, err := boClient.GetNodeLog(organization, "1", "6", 0, "fakePipeline")
+			Expect(err).Should(BeNil())
+			Expect(log.HasMore).Should(BeTrue())
+		})
+		It("Get the log of a step", func() {
+			given("nodes/6/steps/7/log/", 200)
+			log, err := boClient.GetStepLog(organization, "1", "6", "7", 0, "fakePipeline")
+			Expect(err).Should(BeNil())
+			Expect(log.NextStart).Should(Equal(int64(8)))
+		})
+		It("Get the log of a step with an error", func() {
+			given("nodes/6/steps/7/log/", 404)
+			_, err := boClient.GetStepLog(organization, "1", "6", "7", 0, "fakePipeline")
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+
+	Context("Branches", func() {
+		given := func(method, api string, statusCode int, givenResponseJSON string) {
+			request, _ := http.NewRequest(method, fmt.Sprintf("%s/blue/rest/%s", boClient.URL, api), nil)
+			if method == http.MethodPut {
+				request.Header.Set("Content-Type", "application/json")
+			}
+			response := &http.Response{
+				StatusCode: statusCode,
+				Request:    request,
+				Body:       io.NopCloser(bytes.NewBufferString(givenResponseJSON)),
+			}
+			roundTripper.EXPECT().RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+		}
+		It("Get branches of a multi-branch Pipeline", func() {
+			given(http.MethodGet, "organizations/jenkins/pipelines/folder/pipelines/fake/branches/", 200, `
+[{
+  "name": "master",
+  "weatherScore": 100,
+  "branch": {"isPrimary": true, "url": "https://github.com/fake/fake/tree/master"},
+  "latestRun": {"id": "3", "result": "SUCCESS", "state": "FINISHED"},
+  "pullRequest": null
+}]`)
+			branches, err := boClient.GetBranches(organization, 0, 50, "folder", "fake")
+			Expect(err).Should(BeNil())
+			Expect(branches).Should(HaveLen(1))
+			Expect(branches[0].Branch.IsPrimary).Should(BeTrue())
+			Expect(branches[0].LatestRun.Result).Should(Equal(BuildResultSuccess))
+			Expect(branches[0].PullRequest).Should(BeNil())
+			Expect(branches[0].WeatherScore.Weather()).Should(Equal("sunny"))
+		})
+		It("Get pull requests of a multi-branch Pipeline", func() {
+			given(http.MethodGet, "organizations/jenkins/pipelines/fake/pullRequests/", 200, `
+[{
+  "name": "PR-1",
+  "pullRequest": {"author": "fake", "id": "1", "title": "Fix", "url": "https://github.com/fake/fake/pull/1"}
+}]`)
+			pullRequests, err := boClient.GetPullRequests(organization, 0, 50, "fake")
+			Expect(err).Should(BeNil())
+			Expect(pullRequests).Should(HaveLen(1))
+			Expect(pullRequests[0].PullRequest.Author).Should(Equal("fake"))
+			Expect(pullRequests[0].PullRequest.URL).Should(Equal("https://github.com/fake/fake/pull/1"))
+		})
+		It("Get a branch with slash", func() {
+			given(http.MethodGet, "organizations/jenkins/pipelines/fake/branches/feature%252Fdemo/", 200, `{"name": "feature%2Fdemo", "displayName": "feature/demo"}`)
+			branch, err := boClient.GetBranch(organization, "feature%2Fdemo", "fake")
+			Expect(err).Should(BeNil())
+			Expect(branch.DisplayName).Should(Equal("feature/demo"))
+		})
+		It("Get a branch with an error", func() {
+			given(http.MethodGet, "organizations/jenkins/pipelines/fake/branches/master/", 404, "")
+			_, err := boClient.GetBranch(organization, "master", "fake")
+			Expect(err).ShouldNot(BeNil())
+		})
+		It("Get favorites of a user", func() {
+			given(http.MethodGet, "users/admin/favorites/", 200, `[{"item": {"fullName": "fake/master", "name": "master"}}]`)
+			favorites, err := boClient.GetFavorites("admin")
+			Expect(err).Should(BeNil())
+			Expect(favorites).Should(HaveLen(1))
+			Expect(favorites[0].Item.FullName).Should(Equal("fake/master"))
+		})
+		It("Mark a Pipeline as a favorite", func() {
+			given(http.MethodPut, "organizations/jenkins/pipelines/fake/favorite/", 200, `{"item": {"fullName": "fake"}}`)
+			favorite, err := boClient.SetFavorite(organization, true, "fake")
+			Expect(err).Should(BeNil())
+			Expect(favorite.Item.FullName).Should(Equal("fake"))
+		})
+		It("Unmark a branch as a favorite", func() {
+			given(http.MethodPut, "organizations/jenkins/pipelines/fake/branches/master/favorite/", 200, `{"item": {"fullName": "fake/master"}}`)
+			favorite, err := boClient.SetBranchFavorite(organization, "master", false, "fake")
+			Expect(err).Should(BeNil())
+			Expect(favorite.Item.FullName).Should(Equal("fake/master"))
+		})
+	})
+
+	Context("Run control", func() {
+		givenPost := func(api, body, responseBody string) {
+			request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/blue/rest/organizations/%s/pipelines/fake/%s", boClient.URL, organization, api),
+				bytes.NewBufferString(body))
+			request.Header.Set("Content-Type", "application/json")
+			core.PrepareCommonPost(request, responseBody, roundTripper, "", "", boClient.URL)
+		}
+		It("Build with parameters", func() {
+			givenPost("runs/", `{"parameters":[{"name":"env","value":"prod"},{"name":"dryRun","value":true}]}`, `{"id":"4","state":"QUEUED"}`)
+			pipelineBuild, err := boClient.BuildWithParameters(organization, []PipelineParameter{{
+				Name:  "env",
+				Value: "prod",
+			}, {
+				Name:  "dryRun",
+				Value: true,
+			}}, "fake")
+			Expect(err).Should(BeNil())
+			Expect(pipelineBuild.ID).Should(Equal("4"))
+			Expect(pipelineBuild.State).Should(Equal(PipelineStateQueued))
+		})
+		It("Replay a run", func() {
+			givenPost("runs/3/replay/", "", `{"id":"4"}`)
+			pipelineBuild, err := boClient.Replay(organization, "3", "fake")
+			Expect(err).Should(BeNil())
+			Expect(pipelineBuild.ID).Should(Equal("4"))
+		})
+		It("Submit an input", func() {
+			givenPost("runs/3/nodes/6/steps/7/", `{"id":"Fake","parameters":[{"name":"env","value":"prod"}]}`, "")
+			err := boClient.SubmitInput(organization, "3", "6", "7", "Fake", []PipelineParameter{{
+				Name:  "env",
+				Value: "prod",
+			}}, "fake")
+			Expect(err).Should(BeNil())
+		})
+		It("Abort an input", func() {
+			givenPost("runs/3/nodes/6/steps/7/", `{"id":"Fake","abort":true}`, "")
+			err := boClient.AbortInput(organization, "3", "6", "7", "Fake", "fake")
+			Expect(err).Should(BeNil())
+		})
+		It("Stop a run", func() {
+			request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/blue/rest/organizations/%s/pipelines/fake/runs/3/stop/?blocking=true&timeOutInSecs=10", boClient.URL, organization), nil)
+			request.Header.Set("Content-Type", "application/json")
+			response := &http.Response{
+				StatusCode: 200,
+				Request:    request,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"id":"3","result":"ABORTED","state":"FINISHED"}`)),
+			}
+			roundTripper.EXPECT().RoundTrip(core.NewVerboseRequestMatcher(request).WithQuery()).Return(response, nil)
+
+			pipelineBuild, err := boClient.Stop(organization, "3", true, 10, "fake")
+			Expect(err).Should(BeNil())
+			Expect(pipelineBuild.Result).Should(Equal(BuildResultAborted))
+		})
+	})
 })
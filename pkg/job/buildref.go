@@ -0,0 +1,94 @@
+package job
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// BuildRef references a build of a job by its number, permalink or display name
+type BuildRef struct {
+	number      int
+	permalink   string
+	displayName string
+}
+
+var (
+	// LastBuild references the last build of a job
+	LastBuild = BuildRef{permalink: "lastBuild"}
+	// LastSuccessfulBuild references the last successful build of a job
+	LastSuccessfulBuild = BuildRef{permalink: "lastSuccessfulBuild"}
+	// LastFailedBuild references the last failed build of a job
+	LastFailedBuild = BuildRef{permalink: "lastFailedBuild"}
+	// LastStableBuild references the last stable build of a job
+	LastStableBuild = BuildRef{permalink: "lastStableBuild"}
+	// LastUnstableBuild references the last unstable build of a job
+	LastUnstableBuild = BuildRef{permalink: "lastUnstableBuild"}
+	// LastCompletedBuild references the last completed build of a job
+	LastCompletedBuild = BuildRef{permalink: "lastCompletedBuild"}
+)
+
+// BuildNumber references a build by its number
+func BuildNumber(number int) BuildRef {
+	return BuildRef{number: number}
+}
+
+// BuildDisplayName references a build by its display name, it needs to be resolved as a number before using
+func BuildDisplayName(displayName string) BuildRef {
+	return BuildRef{displayName: displayName}
+}
+
+// BuildRefFromID converts a legacy build id to a BuildRef, the id -1 means the last build.
+// The other ids which are less than 1 are invalid, GetBuildPath returns an error for them
+func BuildRefFromID(id int) BuildRef {
+	if id == -1 {
+		return LastBuild
+	}
+	return BuildNumber(id)
+}
+
+// IsDisplayName returns true if the build is referenced by its display name
+func (r BuildRef) IsDisplayName() bool {
+	return r.displayName != ""
+}
+
+// String returns the path segment of the build
+func (r BuildRef) String() string {
+	switch {
+	case r.permalink != "":
+		return r.permalink
+	case r.displayName != "":
+		return r.displayName
+	default:
+		return strconv.Itoa(r.number)
+	}
+}
+
+// GetBuildPath returns the path of a build which leads with slash, the display name will be resolved as the number
+func (q *Client) GetBuildPath(jobName string, ref BuildRef) (path string, err error) {
+	if ref.permalink == "" && !ref.IsDisplayName() && ref.number < 1 {
+		err = fmt.Errorf("invalid build number: %d", ref.number)
+		return
+	}
+
+	path = ParseJobPath(jobName)
+	if !ref.IsDisplayName() {
+		path = fmt.Sprintf("%s/%s", path, ref)
+		return
+	}
+
+	job := struct {
+		AllBuilds []Build
+	}{}
+	api := fmt.Sprintf("%s/api/json?tree=allBuilds[number,displayName]", path)
+	if err = q.RequestWithData(http.MethodGet, api, nil, nil, 200, &job); err == nil {
+		for _, build := range job.AllBuilds {
+			if build.DisplayName == ref.displayName {
+				path = fmt.Sprintf("%s/%d", path, build.Number)
+				return
+			}
+		}
+		err = fmt.Errorf("cannot find the build with display name: %s", ref.displayName)
+	}
+	return
+}
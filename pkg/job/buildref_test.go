@@ -0,0 +1,172 @@
+package job
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBuildRef_String(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  BuildRef
+		want string
+	}{{
+		name: "build number",
+		ref:  BuildNumber(3),
+		want: "3",
+	}, {
+		name: "legacy last build id",
+		ref:  BuildRefFromID(-1),
+		want: "lastBuild",
+	}, {
+		name: "legacy build id 0",
+		ref:  BuildRefFromID(0),
+		want: "0",
+	}, {
+		name: "legacy build id",
+		ref:  BuildRefFromID(2),
+		want: "2",
+	}, {
+		name: "last successful build",
+		ref:  LastSuccessfulBuild,
+		want: "lastSuccessfulBuild",
+	}, {
+		name: "last completed build",
+		ref:  LastCompletedBuild,
+		want: "lastCompletedBuild",
+	}, {
+		name: "display name",
+		ref:  BuildDisplayName("release-1.0"),
+		want: "release-1.0",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ref.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var _ = Describe("build reference test", func() {
+	var (
+		ctrl         *gomock.Controller
+		jobClient    Client
+		roundTripper *mhttp.MockRoundTripper
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		jobClient = Client{}
+		roundTripper = mhttp.NewMockRoundTripper(ctrl)
+		jobClient.RoundTripper = roundTripper
+		jobClient.URL = "http://localhost"
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	givenBuilds := func() {
+		request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/job/fake/api/json", jobClient.URL), nil)
+		response := &http.Response{
+			StatusCode: 200,
+			Request:    request,
+			Body: ioutil.NopCloser(bytes.NewBufferString(`{"allBuilds":[
+{"number":2,"displayName":"release-1.1"},{"number":1,"displayName":"release-1.0"}]}`)),
+		}
+		roundTripper.EXPECT().
+			RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+	}
+
+	Context("GetBuildPath", func() {
+		It("with a permalink", func() {
+			path, err := jobClient.GetBuildPath("fake", LastStableBuild)
+			Expect(err).To(BeNil())
+			Expect(path).To(Equal("/job/fake/lastStableBuild"))
+		})
+
+		It("with a display name", func() {
+			givenBuilds()
+			path, err := jobClient.GetBuildPath("fake", BuildDisplayName("release-1.0"))
+			Expect(err).To(BeNil())
+			Expect(path).To(Equal("/job/fake/1"))
+		})
+
+		It("with a non-existing display name", func() {
+			givenBuilds()
+			_, err := jobClient.GetBuildPath("fake", BuildDisplayName("release-2.0"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("with an invalid build id", func() {
+			_, err := jobClient.GetBuildPath("fake", BuildRefFromID(0))
+			Expect(err).To(HaveOccurred())
+
+			_, err = jobClient.GetBuild("fake", -2)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("GetBuildByRef", func() {
+		It("the last failed build", func() {
+			request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/job/fake/lastFailedBuild/api/json", jobClient.URL), nil)
+			response := &http.Response{
+				StatusCode: 200,
+				Request:    request,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"number":3,"result":"FAILURE"}`)),
+			}
+			roundTripper.EXPECT().
+				RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+
+			build, err := jobClient.GetBuildByRef("fake", LastFailedBuild)
+			Expect(err).To(BeNil())
+			Expect(build.Number).To(Equal(3))
+			Expect(build.Result).To(Equal(BuildResultFailure))
+		})
+	})
+
+	Context("StopBuild", func() {
+		It("stop a build by display name", func() {
+			givenBuilds()
+			request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/job/fake/2/stop", jobClient.URL), nil)
+			core.PrepareCommonPost(request, "", roundTripper, "", "", jobClient.URL)
+
+			err := jobClient.StopBuild("fake", BuildDisplayName("release-1.1"))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Context("LogByRef", func() {
+		It("the log of the last unstable build", func() {
+			request, _ := http.NewRequest(http.MethodGet,
+				fmt.Sprintf("%s/job/fake/lastUnstableBuild/logText/progressiveText?start=0", jobClient.URL), nil)
+			response := &http.Response{
+				StatusCode: 200,
+				Request:    request,
+				Header: map[string][]string{
+					"X-More-Data": {"true"},
+					"X-Text-Size": {"8"},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString("fake log")),
+			}
+			roundTripper.EXPECT().
+				RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+
+			log, err := jobClient.LogByRef("fake", LastUnstableBuild, 0)
+			Expect(err).To(BeNil())
+			Expect(log.Text).To(Equal("fake log"))
+			Expect(log.HasMore).To(BeTrue())
+			Expect(log.NextStart).To(Equal(int64(8)))
+		})
+	})
+})
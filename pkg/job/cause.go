@@ -0,0 +1,145 @@
+package job
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CauseType is the type of the reason why a build is triggered
+type CauseType string
+
+const (
+	// CauseUser means a build was started by a user
+	CauseUser CauseType = "User"
+	// CauseTimer means a build was started by the cron trigger
+	CauseTimer CauseType = "Timer"
+	// CauseSCM means a build was started by the SCM polling or a webhook
+	CauseSCM CauseType = "SCM"
+	// CauseRemote means a build was started by the remote trigger with a token
+	CauseRemote CauseType = "Remote"
+	// CauseReplay means a build was replayed from another one
+	CauseReplay CauseType = "Replay"
+	// CauseBranchIndexing means a build was started by the branch indexing of multi-branch Pipeline
+	CauseBranchIndexing CauseType = "BranchIndexing"
+	// CauseUpstream means a build was started by an upstream build
+	CauseUpstream CauseType = "Upstream"
+	// CauseUnknown means the cause is not recognized
+	CauseUnknown CauseType = "Unknown"
+)
+
+var causeClasses = map[string]CauseType{
+	"hudson.model.Cause$UserIdCause":                                        CauseUser,
+	"hudson.model.Cause$UserCause":                                          CauseUser,
+	"hudson.triggers.TimerTrigger$TimerTriggerCause":                        CauseTimer,
+	"hudson.triggers.SCMTrigger$SCMTriggerCause":                            CauseSCM,
+	"com.cloudbees.jenkins.GitHubPushCause":                                 CauseSCM,
+	"jenkins.branch.BranchEventCause":                                       CauseSCM,
+	"hudson.model.Cause$RemoteCause":                                        CauseRemote,
+	"org.jenkinsci.plugins.workflow.cps.replay.ReplayCause":                 CauseReplay,
+	"jenkins.branch.BranchIndexingCause":                                    CauseBranchIndexing,
+	"hudson.model.Cause$UpstreamCause":                                      CauseUpstream,
+	"org.jenkinsci.plugins.workflow.support.steps.build.BuildUpstreamCause": CauseUpstream,
+	"hudson.model.Cause$UpstreamCause$DeeplyNestedUpstreamCause":            CauseUpstream,
+}
+
+// Cause represents the reason why a build is triggered
+type Cause struct {
+	Class            string `json:"_class"`
+	ShortDescription string `json:"shortDescription"`
+
+	// the user who started the build
+	UserID   string `json:"userId"`
+	UserName string `json:"userName"`
+
+	// the upstream build which started the build
+	UpstreamURL     string  `json:"upstreamUrl"`
+	UpstreamProject string  `json:"upstreamProject"`
+	UpstreamBuild   int     `json:"upstreamBuild"`
+	UpstreamCauses  []Cause `json:"upstreamCauses"`
+
+	// the remote address and note of the remote trigger
+	Addr string `json:"addr"`
+	Note string `json:"note"`
+}
+
+// Type returns the type of the cause, it guesses from the fields if the class is missing
+func (c Cause) Type() CauseType {
+	if causeType, ok := causeClasses[c.Class]; ok {
+		return causeType
+	}
+	switch {
+	case c.UpstreamProject != "":
+		return CauseUpstream
+	case c.UserID != "":
+		return CauseUser
+	case c.Addr != "":
+		return CauseRemote
+	case strings.HasPrefix(c.ShortDescription, "Replayed #"):
+		return CauseReplay
+	}
+	return CauseUnknown
+}
+
+// UpstreamChain returns the upstream builds from the closest one to the farthest one,
+// it's empty if the cause is not an upstream cause
+func (c Cause) UpstreamChain() (chain []Cause) {
+	for cause, ok := c, c.Type() == CauseUpstream; ok; cause, ok = cause.upstream() {
+		chain = append(chain, cause)
+	}
+	return
+}
+
+// RootCause returns the cause which started the farthest upstream build, it returns itself without upstream
+func (c Cause) RootCause() Cause {
+	chain := c.UpstreamChain()
+	if len(chain) == 0 {
+		return c
+	}
+	if farthest := chain[len(chain)-1]; len(farthest.UpstreamCauses) > 0 {
+		return farthest.UpstreamCauses[0]
+	}
+	return chain[len(chain)-1]
+}
+
+func (c Cause) upstream() (Cause, bool) {
+	for _, cause := range c.UpstreamCauses {
+		if cause.Type() == CauseUpstream {
+			return cause, true
+		}
+	}
+	return Cause{}, false
+}
+
+// ParameterValue is the value of a build parameter
+type ParameterValue struct {
+	Class string      `json:"_class"`
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// String returns the value as a string
+func (p ParameterValue) String() string {
+	if p.Value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", p.Value)
+}
+
+// BuildActions are the actions of a build or a queue item
+type BuildActions []BuildAction
+
+// Causes returns the causes of all the cause actions
+func (a BuildActions) Causes() (causes []Cause) {
+	for _, action := range a {
+		causes = append(causes, action.Causes...)
+	}
+	return
+}
+
+// Parameters returns the parameters of all the parameters actions
+func (a BuildActions) Parameters() (parameters []ParameterValue) {
+	for _, action := range a {
+		parameters = append(parameters, action.Parameters...)
+	}
+	return
+}
@@ -0,0 +1,98 @@
+package job
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCause_Type(t *testing.T) {
+	tests := []struct {
+		name  string
+		cause Cause
+		want  CauseType
+	}{{
+		name:  "user",
+		cause: Cause{Class: "hudson.model.Cause$UserIdCause", UserID: "admin"},
+		want:  CauseUser,
+	}, {
+		name:  "timer",
+		cause: Cause{Class: "hudson.triggers.TimerTrigger$TimerTriggerCause"},
+		want:  CauseTimer,
+	}, {
+		name:  "SCM",
+		cause: Cause{Class: "hudson.triggers.SCMTrigger$SCMTriggerCause"},
+		want:  CauseSCM,
+	}, {
+		name:  "remote",
+		cause: Cause{Class: "hudson.model.Cause$RemoteCause", Addr: "127.0.0.1"},
+		want:  CauseRemote,
+	}, {
+		name:  "replay",
+		cause: Cause{Class: "org.jenkinsci.plugins.workflow.cps.replay.ReplayCause"},
+		want:  CauseReplay,
+	}, {
+		name:  "branch indexing",
+		cause: Cause{Class: "jenkins.branch.BranchIndexingCause"},
+		want:  CauseBranchIndexing,
+	}, {
+		name:  "upstream without class",
+		cause: Cause{UpstreamProject: "parent"},
+		want:  CauseUpstream,
+	}, {
+		name:  "user without class",
+		cause: Cause{UserID: "admin"},
+		want:  CauseUser,
+	}, {
+		name:  "unknown",
+		cause: Cause{Class: "fake"},
+		want:  CauseUnknown,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cause.Type(); got != tt.want {
+				t.Errorf("Type() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCauses(t *testing.T) {
+	build := &Build{}
+	if err := json.Unmarshal([]byte(`{"actions":[{
+  "_class": "hudson.model.CauseAction",
+  "causes": [{
+    "_class": "hudson.model.Cause$UpstreamCause",
+    "upstreamProject": "parent", "upstreamBuild": 3, "upstreamUrl": "job/parent/",
+    "upstreamCauses": [{
+      "_class": "hudson.model.Cause$UpstreamCause",
+      "upstreamProject": "root", "upstreamBuild": 1, "upstreamUrl": "job/root/",
+      "upstreamCauses": [{"_class": "hudson.model.Cause$UserIdCause", "userId": "admin", "userName": "Admin"}]
+    }]
+  }]
+}, {
+  "_class": "hudson.model.ParametersAction",
+  "parameters": [{"_class": "hudson.model.BooleanParameterValue", "name": "dryRun", "value": true}]
+}, {}]}`), build); err != nil {
+		t.Fatalf("failed to unmarshal build: %v", err)
+	}
+
+	causes := build.Actions.Causes()
+	if len(causes) != 1 {
+		t.Fatalf("got %d causes, want 1", len(causes))
+	}
+	chain := causes[0].UpstreamChain()
+	if len(chain) != 2 || chain[0].UpstreamProject != "parent" || chain[1].UpstreamProject != "root" {
+		t.Errorf("unexpected upstream chain: %+v", chain)
+	}
+	if root := causes[0].RootCause(); root.Type() != CauseUser || root.UserID != "admin" {
+		t.Errorf("unexpected root cause: %+v", root)
+	}
+	if root := chain[1].UpstreamCauses[0].RootCause(); root.UserID != "admin" {
+		t.Errorf("the root cause of a non-upstream cause should be itself, got %+v", root)
+	}
+
+	parameters := build.Actions.Parameters()
+	if len(parameters) != 1 || parameters[0].Name != "dryRun" || parameters[0].String() != "true" {
+		t.Errorf("unexpected parameters: %+v", parameters)
+	}
+}
@@ -57,6 +57,40 @@ func (q *Client) Build(jobName string) (err error) {
 	return
 }
 
+// TriggerBuild triggers a job then returns the id of the queue item, see also queue.Client.WaitForBuild
+func (q *Client) TriggerBuild(jobName string) (queueItemID int, err error) {
+	path := ParseJobPath(jobName)
+	var response *http.Response
+	if response, err = q.RequestWithResponse(http.MethodPost, fmt.Sprintf("%s/build", path), nil, nil); err != nil {
+		return
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusCreated {
+		var data []byte
+		data, _ = ioutil.ReadAll(response.Body)
+		err = q.ErrorHandle(response.StatusCode, data)
+		return
+	}
+	queueItemID, err = ParseQueueItemID(response.Header.Get("Location"))
+	return
+}
+
+// ParseQueueItemID returns the id of a queue item from its URL, e.g. http://localhost/queue/item/12/
+func ParseQueueItemID(itemURL string) (id int, err error) {
+	segments := strings.Split(strings.TrimSuffix(itemURL, "/"), "/")
+	if len(segments) < 3 || segments[len(segments)-3] != "queue" || segments[len(segments)-2] != "item" {
+		err = fmt.Errorf("invalid URL of queue item: %s", itemURL)
+		return
+	}
+	if id, err = strconv.Atoi(segments[len(segments)-1]); err != nil {
+		err = fmt.Errorf("invalid URL of queue item: %s", itemURL)
+	}
+	return
+}
+
 // IdentityBuild is the build which carry the identity cause
 type IdentityBuild struct {
 	Build Build
@@ -89,17 +123,17 @@ func (q *Client) BuildAndReturn(jobName, cause string, timeout, delay int) (buil
 	return
 }
 
-// GetBuild get build information of a job
+// GetBuild get build information of a job, the id -1 means the last build
 func (q *Client) GetBuild(jobName string, id int) (job *Build, err error) {
-	path := ParseJobPath(jobName)
-	var api string
-	if id == -1 {
-		api = fmt.Sprintf("%s/lastBuild/api/json", path)
-	} else {
-		api = fmt.Sprintf("%s/%d/api/json", path, id)
-	}
+	return q.GetBuildByRef(jobName, BuildRefFromID(id))
+}
 
-	err = q.RequestWithData("GET", api, nil, nil, 200, &job)
+// GetBuildByRef get build information of a job by a build reference
+func (q *Client) GetBuildByRef(jobName string, ref BuildRef) (job *Build, err error) {
+	var path string
+	if path, err = q.GetBuildPath(jobName, ref); err == nil {
+		err = q.RequestWithData(http.MethodGet, fmt.Sprintf("%s/api/json", path), nil, nil, 200, &job)
+	}
 	return
 }
 
@@ -190,18 +224,20 @@ func (q *Client) EnableJob(jobName string) (err error) {
 	return
 }
 
-// StopJob stops a job build
+// StopJob stops a job build, the number which is less than 1 means the last build
 func (q *Client) StopJob(jobName string, num int) (err error) {
-	path := ParseJobPath(jobName)
-
-	var api string
-	if num <= 0 {
-		api = fmt.Sprintf("%s/lastBuild/stop", path)
-	} else {
-		api = fmt.Sprintf("%s/%d/stop", path, num)
+	if num < 1 {
+		return q.StopBuild(jobName, LastBuild)
 	}
+	return q.StopBuild(jobName, BuildNumber(num))
+}
 
-	_, err = q.RequestWithoutData(http.MethodPost, api, nil, nil, 200)
+// StopBuild stops a job build by a build reference
+func (q *Client) StopBuild(jobName string, ref BuildRef) (err error) {
+	var path string
+	if path, err = q.GetBuildPath(jobName, ref); err == nil {
+		_, err = q.RequestWithoutData(http.MethodPost, fmt.Sprintf("%s/stop", path), nil, nil, 200)
+	}
 	return
 }
 
@@ -304,15 +340,19 @@ func (q *Client) DeleteHistory(jobName string, num int) (err error) {
 	return
 }
 
-// Log get the log of a job
+// Log get the log of a job, the history -1 means the last build
 func (q *Client) Log(jobName string, history int, start int64) (jobLog Log, err error) {
-	path := ParseJobPath(jobName)
-	var api string
-	if history == -1 {
-		api = fmt.Sprintf("%s%s/lastBuild/logText/progressiveText?start=%d", q.URL, path, start)
-	} else {
-		api = fmt.Sprintf("%s%s/%d/logText/progressiveText?start=%d", q.URL, path, history, start)
+	return q.LogByRef(jobName, BuildRefFromID(history), start)
+}
+
+// LogByRef get the log of a job build by a build reference
+func (q *Client) LogByRef(jobName string, ref BuildRef, start int64) (jobLog Log, err error) {
+	var path string
+	if path, err = q.GetBuildPath(jobName, ref); err != nil {
+		return
 	}
+
+	api := fmt.Sprintf("%s%s/logText/progressiveText?start=%d", q.URL, path, start)
 	var (
 		req      *http.Request
 		response *http.Response
@@ -498,7 +538,7 @@ type JenkinsItem struct {
 
 	/** comes from blueOcean */
 	FullName     string
-	WeatherScore int
+	WeatherScore HealthScore
 	Parameters   []ParameterDefinition
 }
 
@@ -506,8 +546,9 @@ type JenkinsItem struct {
 type Job struct {
 	Type            string `json:"_class"`
 	Builds          []Build
-	Color           string
+	Color           BallColor
 	ConcurrentBuild bool
+	HealthReport    []HealthReport
 	Name            string
 	NextBuildNumber int
 	URL             string
@@ -555,10 +596,26 @@ type Build struct {
 	ID                string
 	KeepLog           bool
 	QueueID           int
-	Result            string
-	Timestamp         int64
+	Result            BuildResult
+	Timestamp         Time
 	PreviousBuild     SimpleJobBuild
 	NextBuild         SimpleJobBuild
+	Actions           BuildActions
+}
+
+// BuildAction represents an action of a build, only the known fields are parsed
+type BuildAction struct {
+	Class string `json:"_class"`
+
+	// the fields of CauseAction and ParametersAction
+	Causes     []Cause          `json:"causes"`
+	Parameters []ParameterValue `json:"parameters"`
+
+	// the fields of TimeInQueueAction which comes from the metrics plugin
+	QueuingDurationMillis   int64 `json:"queuingDurationMillis"`
+	BlockedDurationMillis   int64 `json:"blockedDurationMillis"`
+	BuildableDurationMillis int64 `json:"buildableDurationMillis"`
+	WaitingDurationMillis   int64 `json:"waitingDurationMillis"`
 }
 
 // Pipeline represents a pipeline
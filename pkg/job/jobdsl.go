@@ -0,0 +1,151 @@
+package job
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	httpdownloader "github.com/linuxsuren/http-downloader/pkg"
+)
+
+const (
+	// WorkflowJobMode is the mode for creating a Pipeline job
+	WorkflowJobMode = "org.jenkinsci.plugins.workflow.job.WorkflowJob"
+
+	generatedJobsActionClass  = "javaposse.jobdsl.plugin.actions.GeneratedJobsBuildAction"
+	generatedViewsActionClass = "javaposse.jobdsl.plugin.actions.GeneratedViewsBuildAction"
+)
+
+// DSLOptions holds the options of the jobDsl step in a seed job
+type DSLOptions struct {
+	// RemovedJobAction could be IGNORE, DISABLE or DELETE
+	RemovedJobAction string
+	// RemovedViewAction could be IGNORE or DELETE
+	RemovedViewAction string
+	// LookupStrategy could be JENKINS_ROOT or SEED_JOB
+	LookupStrategy string
+	IgnoreExisting bool
+	Sandbox        bool
+}
+
+// DSLResult is the result of a seed job build
+type DSLResult struct {
+	Building bool
+	Result   BuildResult
+	Items    []GeneratedItem
+	Views    []GeneratedItem
+}
+
+// GeneratedItem represents a job or view which generated by Job DSL
+type GeneratedItem struct {
+	Name     string
+	FullName string
+	URL      string
+}
+
+// ScriptIssue represents a problem of a Groovy script
+type ScriptIssue struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// CreateSeedJob creates a Pipeline job which is used to run Job DSL scripts
+func (q *Client) CreateSeedJob(name, folder string) (err error) {
+	return q.CreateJobInFolder(CreateJobPayload{
+		Name: name,
+		Mode: WorkflowJobMode,
+	}, folder)
+}
+
+// SubmitDSL puts the Job DSL script into a seed job then triggers it, returns the id of the queue item.
+// The build could be resolved by queue.Client.WaitForBuild
+func (q *Client) SubmitDSL(seedJob, script string, options DSLOptions) (queueItemID int, err error) {
+	if err = q.UpdatePipeline(seedJob, GetSeedPipelineScript(script, options)); err == nil {
+		queueItemID, err = q.TriggerBuild(seedJob)
+	}
+	return
+}
+
+// GetDSLResult returns the items which generated by a build of the seed job
+func (q *Client) GetDSLResult(seedJob string, ref BuildRef) (result *DSLResult, err error) {
+	var path string
+	if path, err = q.GetBuildPath(seedJob, ref); err != nil {
+		return
+	}
+	api := fmt.Sprintf("%s/api/json?tree=building,result,actions[_class,items[name,fullName,url],views[name,url]]", path)
+
+	build := struct {
+		Building bool
+		Result   BuildResult
+		Actions  []struct {
+			Class string `json:"_class"`
+			Items []GeneratedItem
+			Views []GeneratedItem
+		}
+	}{}
+	if err = q.RequestWithData(http.MethodGet, api, nil, nil, 200, &build); err == nil {
+		result = &DSLResult{
+			Building: build.Building,
+			Result:   build.Result,
+		}
+
+		for _, action := range build.Actions {
+			switch action.Class {
+			case generatedJobsActionClass:
+				result.Items = append(result.Items, action.Items...)
+			case generatedViewsActionClass:
+				result.Views = append(result.Views, action.Views...)
+			}
+		}
+	}
+	return
+}
+
+// CheckDSLSyntax checks the Groovy syntax of a Job DSL script, returns the issues if there are.
+// It uses the script checker of Pipeline, so the script is only compiled as a generic Groovy script,
+// the methods of Job DSL, e.g. job or pipelineJob, are not checked against the Job DSL API
+func (q *Client) CheckDSLSyntax(script string) (issues []ScriptIssue, err error) {
+	formData := url.Values{"value": {script}}
+	payload := strings.NewReader(formData.Encode())
+
+	var result []ScriptIssue
+	if err = q.RequestWithData(http.MethodPost,
+		"/descriptorByName/org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition/checkScriptCompile",
+		map[string]string{httpdownloader.ContentType: httpdownloader.ApplicationForm}, payload, 200, &result); err == nil {
+		for _, issue := range result {
+			if issue.Status != "success" {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return
+}
+
+// GetSeedPipelineScript returns a Pipeline script which runs the Job DSL script
+func GetSeedPipelineScript(script string, options DSLOptions) string {
+	// the script is in a single-quoted string, so there's no interpolation of $
+	escaped := strings.ReplaceAll(script, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `'`, `\'`)
+
+	return fmt.Sprintf(`node {
+    jobDsl scriptText: '''%s''',
+        removedJobAction: '%s',
+        removedViewAction: '%s',
+        lookupStrategy: '%s',
+        ignoreExisting: %t,
+        sandbox: %t
+}`, escaped, defaultString(options.RemovedJobAction, "IGNORE"),
+		defaultString(options.RemovedViewAction, "IGNORE"),
+		defaultString(options.LookupStrategy, "JENKINS_ROOT"),
+		options.IgnoreExisting, options.Sandbox)
+}
+
+func defaultString(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,152 @@
+package job
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
+	httpdownloader "github.com/linuxsuren/http-downloader/pkg"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Job DSL test", func() {
+	var (
+		ctrl         *gomock.Controller
+		jobClient    Client
+		roundTripper *mhttp.MockRoundTripper
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		jobClient = Client{}
+		roundTripper = mhttp.NewMockRoundTripper(ctrl)
+		jobClient.RoundTripper = roundTripper
+		jobClient.URL = "http://localhost"
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("CreateSeedJob", func() {
+		It("should success", func() {
+			PrepareForCreatePipelineJob(roundTripper, jobClient.URL, "", "", CreateJobPayload{
+				Name: "seed",
+				Mode: WorkflowJobMode,
+			})
+			err := jobClient.CreateSeedJob("seed", "")
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Context("SubmitDSL", func() {
+		It("should success", func() {
+			script := GetSeedPipelineScript("job('fake')", DSLOptions{})
+			core.PrepareForUpdatePipelineJob(roundTripper, jobClient.URL, script, "", "")
+
+			request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/job/test/build", jobClient.URL), nil)
+			response := core.PrepareCommonPost(request, "", roundTripper, "", "", jobClient.URL)
+			response.StatusCode = 201
+			response.Header = http.Header{"Location": []string{"http://localhost/queue/item/12/"}}
+
+			queueItemID, err := jobClient.SubmitDSL("test", "job('fake')", DSLOptions{})
+			Expect(err).To(BeNil())
+			Expect(queueItemID).To(Equal(12))
+		})
+	})
+
+	Context("ParseQueueItemID", func() {
+		It("should success", func() {
+			id, err := ParseQueueItemID("http://localhost/jenkins/queue/item/12/")
+			Expect(err).To(BeNil())
+			Expect(id).To(Equal(12))
+		})
+
+		It("an invalid URL", func() {
+			_, err := ParseQueueItemID("http://localhost/job/test/12/")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("GetDSLResult", func() {
+		It("should success", func() {
+			request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/job/seed/3/api/json", jobClient.URL), nil)
+			response := &http.Response{
+				StatusCode: 200,
+				Request:    request,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{
+  "building": false,
+  "result": "SUCCESS",
+  "actions": [{}, {
+    "_class": "javaposse.jobdsl.plugin.actions.GeneratedJobsBuildAction",
+    "items": [{"name": "fake", "fullName": "folder/fake", "url": "http://localhost/job/folder/job/fake/"}]
+  }, {
+    "_class": "javaposse.jobdsl.plugin.actions.GeneratedViewsBuildAction",
+    "views": [{"name": "view", "url": "http://localhost/view/view/"}]
+  }]
+}`)),
+			}
+			roundTripper.EXPECT().
+				RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+
+			result, err := jobClient.GetDSLResult("seed", BuildNumber(3))
+			Expect(err).To(BeNil())
+			Expect(result.Building).To(BeFalse())
+			Expect(result.Result).To(Equal(BuildResultSuccess))
+			Expect(result.Items).To(HaveLen(1))
+			Expect(result.Items[0].FullName).To(Equal("folder/fake"))
+			Expect(result.Views).To(HaveLen(1))
+			Expect(result.Views[0].Name).To(Equal("view"))
+		})
+	})
+
+	Context("CheckDSLSyntax", func() {
+		given := func(script, responseBody string) {
+			formData := url.Values{"value": {script}}
+			request, _ := http.NewRequest(http.MethodPost,
+				fmt.Sprintf("%s/descriptorByName/org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition/checkScriptCompile", jobClient.URL),
+				strings.NewReader(formData.Encode()))
+			request.Header.Add(httpdownloader.ContentType, httpdownloader.ApplicationForm)
+			core.PrepareCommonPost(request, responseBody, roundTripper, "", "", jobClient.URL)
+		}
+
+		It("a valid script", func() {
+			given("job('fake')", `[{"status":"success"}]`)
+			issues, err := jobClient.CheckDSLSyntax("job('fake')")
+			Expect(err).To(BeNil())
+			Expect(issues).To(BeEmpty())
+		})
+
+		It("an invalid script", func() {
+			given("job('fake'", `[{"line":1,"column":11,"message":"unexpected token","status":"fail"}]`)
+			issues, err := jobClient.CheckDSLSyntax("job('fake'")
+			Expect(err).To(BeNil())
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0].Line).To(Equal(1))
+			Expect(issues[0].Column).To(Equal(11))
+		})
+	})
+
+	Context("GetSeedPipelineScript", func() {
+		It("escape the script", func() {
+			script := GetSeedPipelineScript(`println '''a\b'''`, DSLOptions{RemovedJobAction: "DELETE", Sandbox: true})
+			Expect(script).To(ContainSubstring(`scriptText: '''println \'\'\'a\\b\'\'\''''`))
+			Expect(script).To(ContainSubstring(`removedJobAction: 'DELETE'`))
+			Expect(script).To(ContainSubstring(`removedViewAction: 'IGNORE'`))
+			Expect(script).To(ContainSubstring(`lookupStrategy: 'JENKINS_ROOT'`))
+			Expect(script).To(ContainSubstring(`sandbox: true`))
+		})
+
+		It("escape the quotes", func() {
+			script := GetSeedPipelineScript(`job('a') { description 'it costs $1' }`+"\n'", DSLOptions{})
+			Expect(script).To(ContainSubstring(`scriptText: '''job(\'a\') { description \'it costs $1\' }` + "\n" + `\'''',`))
+		})
+	})
+})
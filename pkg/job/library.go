@@ -0,0 +1,273 @@
+package job
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/casc"
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"gopkg.in/yaml.v2"
+)
+
+const librariesActionClass = "org.jenkinsci.plugins.workflow.libs.LibrariesAction"
+
+// SharedLibraryClient manages the Global Pipeline Libraries via configuration-as-code
+type SharedLibraryClient struct {
+	core.JenkinsCore
+}
+
+// SharedLibrary represents a definition of Global Pipeline Library
+type SharedLibrary struct {
+	Name                 string           `yaml:"name"`
+	DefaultVersion       string           `yaml:"defaultVersion,omitempty"`
+	Implicit             bool             `yaml:"implicit"`
+	AllowVersionOverride bool             `yaml:"allowVersionOverride"`
+	IncludeInChangesets  bool             `yaml:"includeInChangesets"`
+	Retriever            LibraryRetriever `yaml:"retriever"`
+}
+
+// LibraryRetriever represents the way of retrieving a library
+type LibraryRetriever struct {
+	ModernSCM *ModernSCMRetriever `yaml:"modernSCM,omitempty"`
+}
+
+// ModernSCMRetriever retrieves a library from a SCM source
+type ModernSCMRetriever struct {
+	SCM         LibrarySCM `yaml:"scm"`
+	LibraryPath string     `yaml:"libraryPath,omitempty"`
+}
+
+// LibrarySCM is the SCM source of a library
+type LibrarySCM struct {
+	Git    *GitSCMSource    `yaml:"git,omitempty"`
+	GitHub *GitHubSCMSource `yaml:"github,omitempty"`
+}
+
+// GitSCMSource is a plain Git SCM source
+type GitSCMSource struct {
+	Remote        string `yaml:"remote"`
+	CredentialsID string `yaml:"credentialsId,omitempty"`
+}
+
+// GitHubSCMSource is a GitHub SCM source
+type GitHubSCMSource struct {
+	RepoOwner     string `yaml:"repoOwner"`
+	Repository    string `yaml:"repository"`
+	CredentialsID string `yaml:"credentialsId,omitempty"`
+}
+
+// LoadedLibrary represents a library which loaded by a Pipeline run
+type LoadedLibrary struct {
+	Name     string
+	Version  string
+	Implicit bool
+	Trusted  bool
+}
+
+type globalLibrariesConfig struct {
+	Unclassified struct {
+		GlobalLibraries struct {
+			Libraries []yaml.MapSlice `yaml:"libraries"`
+		} `yaml:"globalLibraries"`
+	} `yaml:"unclassified"`
+}
+
+// List returns all the Global Pipeline Libraries
+func (c *SharedLibraryClient) List() (libraries []SharedLibrary, err error) {
+	var rawLibraries []yaml.MapSlice
+	if rawLibraries, err = c.getRawLibraries(); err != nil {
+		return
+	}
+
+	libraries = make([]SharedLibrary, 0, len(rawLibraries))
+	for _, rawLibrary := range rawLibraries {
+		var library SharedLibrary
+		if library, err = toSharedLibrary(rawLibrary); err != nil {
+			return
+		}
+		libraries = append(libraries, library)
+	}
+	return
+}
+
+// Get returns a Global Pipeline Library by name
+func (c *SharedLibraryClient) Get(name string) (library *SharedLibrary, err error) {
+	var libraries []SharedLibrary
+	if libraries, err = c.List(); err == nil {
+		for i := range libraries {
+			if libraries[i].Name == name {
+				library = &libraries[i]
+				return
+			}
+		}
+		err = fmt.Errorf("cannot find the library: %s", name)
+	}
+	return
+}
+
+// Add adds a new Global Pipeline Library
+func (c *SharedLibraryClient) Add(library SharedLibrary) (err error) {
+	var rawLibraries []yaml.MapSlice
+	if rawLibraries, err = c.getRawLibraries(); err != nil {
+		return
+	}
+
+	if index := indexOfLibrary(rawLibraries, library.Name); index != -1 {
+		err = fmt.Errorf("library %s already exists", library.Name)
+		return
+	}
+
+	var rawLibrary yaml.MapSlice
+	if rawLibrary, err = toRawLibrary(library); err == nil {
+		err = c.apply(append(rawLibraries, rawLibrary))
+	}
+	return
+}
+
+// Update updates an existing Global Pipeline Library, other libraries keep untouched.
+// The fields are merged into the existing one, so the settings which are not in SharedLibrary are kept,
+// e.g. cachingConfiguration, the traits of SCM or a retriever other than modernSCM
+func (c *SharedLibraryClient) Update(library SharedLibrary) (err error) {
+	var rawLibraries []yaml.MapSlice
+	if rawLibraries, err = c.getRawLibraries(); err != nil {
+		return
+	}
+
+	index := indexOfLibrary(rawLibraries, library.Name)
+	if index == -1 {
+		err = fmt.Errorf("cannot find the library: %s", library.Name)
+		return
+	}
+
+	var rawLibrary yaml.MapSlice
+	if rawLibrary, err = toRawLibrary(library); err == nil {
+		rawLibraries[index] = mergeMapSlice(rawLibraries[index], rawLibrary)
+		err = c.apply(rawLibraries)
+	}
+	return
+}
+
+func (c *SharedLibraryClient) getRawLibraries() (libraries []yaml.MapSlice, err error) {
+	manager := casc.Manager{JenkinsCore: c.JenkinsCore}
+
+	var config string
+	if config, err = manager.Export(); err == nil {
+		cascConfig := globalLibrariesConfig{}
+		if err = yaml.Unmarshal([]byte(config), &cascConfig); err == nil {
+			libraries = cascConfig.Unclassified.GlobalLibraries.Libraries
+		}
+	}
+	return
+}
+
+func (c *SharedLibraryClient) apply(libraries []yaml.MapSlice) (err error) {
+	cascConfig := globalLibrariesConfig{}
+	cascConfig.Unclassified.GlobalLibraries.Libraries = libraries
+
+	var data []byte
+	if data, err = yaml.Marshal(cascConfig); err == nil {
+		manager := casc.Manager{JenkinsCore: c.JenkinsCore}
+		err = manager.ApplyConfig(string(data))
+	}
+	return
+}
+
+// GetBuildLibraries returns the libraries which loaded by a Pipeline run
+func (q *Client) GetBuildLibraries(jobName string, ref BuildRef) (libraries []LoadedLibrary, err error) {
+	var path string
+	if path, err = q.GetBuildPath(jobName, ref); err != nil {
+		return
+	}
+	api := fmt.Sprintf("%s/api/json?tree=actions[_class,libraries[name,version,implicit,trusted]]", path)
+
+	build := struct {
+		Actions []struct {
+			Class     string `json:"_class"`
+			Libraries []LoadedLibrary
+		}
+	}{}
+	if err = q.RequestWithData(http.MethodGet, api, nil, nil, 200, &build); err == nil {
+		libraries = []LoadedLibrary{}
+		for _, action := range build.Actions {
+			if action.Class == librariesActionClass {
+				libraries = append(libraries, action.Libraries...)
+			}
+		}
+	}
+	return
+}
+
+func indexOfLibrary(libraries []yaml.MapSlice, name string) int {
+	for i, library := range libraries {
+		for _, item := range library {
+			if item.Key == "name" && item.Value == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func toSharedLibrary(rawLibrary yaml.MapSlice) (library SharedLibrary, err error) {
+	var data []byte
+	if data, err = yaml.Marshal(rawLibrary); err == nil {
+		err = yaml.Unmarshal(data, &library)
+	}
+	return
+}
+
+func toRawLibrary(library SharedLibrary) (rawLibrary yaml.MapSlice, err error) {
+	var data []byte
+	if data, err = yaml.Marshal(library); err == nil {
+		err = yaml.Unmarshal(data, &rawLibrary)
+	}
+	return
+}
+
+// choiceKeys are the keys whose value has only one key which is the type, e.g. retriever: {modernSCM: {}}
+var choiceKeys = map[string]bool{"retriever": true, "scm": true}
+
+// mergeMapSlice merges the values into the existing map recursively, the order of the existing keys is kept
+func mergeMapSlice(existing, values yaml.MapSlice) (merged yaml.MapSlice) {
+	merged = append(yaml.MapSlice{}, existing...)
+	for _, item := range values {
+		index := -1
+		for i := range merged {
+			if merged[i].Key == item.Key {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			merged = append(merged, item)
+			continue
+		}
+
+		existingValue, existingIsMap := merged[index].Value.(yaml.MapSlice)
+		value, isMap := item.Value.(yaml.MapSlice)
+		switch {
+		case existingIsMap && isMap && isChoiceChanged(item.Key, existingValue, value):
+			merged[index].Value = value
+		case existingIsMap && isMap:
+			merged[index].Value = mergeMapSlice(existingValue, value)
+		default:
+			merged[index].Value = item.Value
+		}
+	}
+	return
+}
+
+// isChoiceChanged returns true if the type of a choice value is changed, e.g. from git to github
+func isChoiceChanged(key interface{}, existing, value yaml.MapSlice) bool {
+	if name, ok := key.(string); !ok || !choiceKeys[name] || len(value) == 0 {
+		return false
+	}
+	for _, item := range value {
+		for _, existingItem := range existing {
+			if item.Key == existingItem.Key {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,344 @@
+package job
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jenkins-zh/jenkins-client/pkg/casc"
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const sampleLibrariesConfig = `jenkins:
+  systemMessage: "fake"
+unclassified:
+  globalLibraries:
+    libraries:
+    - defaultVersion: "master"
+      name: "common"
+      implicit: true
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              remote: "https://github.com/fake/common.git"
+      cachingConfiguration:
+        refreshTimeMinutes: 60
+    - name: "tools"
+      retriever:
+        modernSCM:
+          scm:
+            github:
+              repoOwner: "fake"
+              repository: "tools"
+`
+
+var _ = Describe("shared library test", func() {
+	var (
+		ctrl          *gomock.Controller
+		libraryClient SharedLibraryClient
+		roundTripper  *mhttp.MockRoundTripper
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		libraryClient = SharedLibraryClient{}
+		roundTripper = mhttp.NewMockRoundTripper(ctrl)
+		libraryClient.RoundTripper = roundTripper
+		libraryClient.URL = "http://localhost"
+
+		response := casc.PrepareForSASCExport(roundTripper, libraryClient.URL, "", "")
+		response.Body = ioutil.NopCloser(bytes.NewBufferString(sampleLibrariesConfig))
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("List", func() {
+		libraries, err := libraryClient.List()
+		Expect(err).To(BeNil())
+		Expect(libraries).To(HaveLen(2))
+		Expect(libraries[0].Name).To(Equal("common"))
+		Expect(libraries[0].DefaultVersion).To(Equal("master"))
+		Expect(libraries[0].Implicit).To(BeTrue())
+		Expect(libraries[0].Retriever.ModernSCM.SCM.Git.Remote).To(Equal("https://github.com/fake/common.git"))
+		Expect(libraries[1].Retriever.ModernSCM.SCM.GitHub.Repository).To(Equal("tools"))
+	})
+
+	It("Get a non-existing library", func() {
+		_, err := libraryClient.Get("fake")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Add an existing library", func() {
+		err := libraryClient.Add(SharedLibrary{Name: "common"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Add a library", func() {
+		casc.PrepareForSASCApplyConfig(roundTripper, libraryClient.URL, "", "", `unclassified:
+  globalLibraries:
+    libraries:
+    - defaultVersion: master
+      name: common
+      implicit: true
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              remote: https://github.com/fake/common.git
+      cachingConfiguration:
+        refreshTimeMinutes: 60
+    - name: tools
+      retriever:
+        modernSCM:
+          scm:
+            github:
+              repoOwner: fake
+              repository: tools
+    - name: new
+      defaultVersion: main
+      implicit: false
+      allowVersionOverride: true
+      includeInChangesets: false
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              remote: https://github.com/fake/new.git
+`)
+
+		err := libraryClient.Add(SharedLibrary{
+			Name:                 "new",
+			DefaultVersion:       "main",
+			AllowVersionOverride: true,
+			Retriever: LibraryRetriever{ModernSCM: &ModernSCMRetriever{
+				SCM: LibrarySCM{Git: &GitSCMSource{Remote: "https://github.com/fake/new.git"}},
+			}},
+		})
+		Expect(err).To(BeNil())
+	})
+
+	It("Update a library", func() {
+		casc.PrepareForSASCApplyConfig(roundTripper, libraryClient.URL, "", "", `unclassified:
+  globalLibraries:
+    libraries:
+    - defaultVersion: master
+      name: common
+      implicit: true
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              remote: https://github.com/fake/common.git
+      cachingConfiguration:
+        refreshTimeMinutes: 60
+    - name: tools
+      retriever:
+        modernSCM:
+          scm:
+            github:
+              repoOwner: fake
+              repository: tools
+      defaultVersion: v1
+      implicit: true
+      allowVersionOverride: false
+      includeInChangesets: false
+`)
+
+		err := libraryClient.Update(SharedLibrary{
+			Name:           "tools",
+			DefaultVersion: "v1",
+			Implicit:       true,
+			Retriever: LibraryRetriever{ModernSCM: &ModernSCMRetriever{
+				SCM: LibrarySCM{GitHub: &GitHubSCMSource{RepoOwner: "fake", Repository: "tools"}},
+			}},
+		})
+		Expect(err).To(BeNil())
+	})
+
+	It("Update a non-existing library", func() {
+		err := libraryClient.Update(SharedLibrary{Name: "fake"})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("shared library round trip test", func() {
+	var (
+		ctrl          *gomock.Controller
+		libraryClient SharedLibraryClient
+		roundTripper  *mhttp.MockRoundTripper
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		libraryClient = SharedLibraryClient{}
+		roundTripper = mhttp.NewMockRoundTripper(ctrl)
+		libraryClient.RoundTripper = roundTripper
+		libraryClient.URL = "http://localhost"
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("keep the settings which are not modelled", func() {
+		// one for Get, another one for Update
+		for i := 0; i < 2; i++ {
+			response := casc.PrepareForSASCExport(roundTripper, libraryClient.URL, "", "")
+			response.Body = ioutil.NopCloser(bytes.NewBufferString(`unclassified:
+  globalLibraries:
+    libraries:
+    - name: "common"
+      defaultVersion: "master"
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              remote: "https://github.com/fake/common.git"
+              traits:
+              - gitBranchDiscovery
+      cachingConfiguration:
+        refreshTimeMinutes: 60
+    - name: "legacy"
+      retriever:
+        legacySCM:
+          scm:
+            svn:
+              remote: "https://svn.fake/legacy"
+`))
+		}
+		casc.PrepareForSASCApplyConfig(roundTripper, libraryClient.URL, "", "", `unclassified:
+  globalLibraries:
+    libraries:
+    - name: common
+      defaultVersion: master
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              remote: https://github.com/fake/common.git
+              traits:
+              - gitBranchDiscovery
+      cachingConfiguration:
+        refreshTimeMinutes: 60
+    - name: legacy
+      retriever:
+        legacySCM:
+          scm:
+            svn:
+              remote: https://svn.fake/legacy
+      defaultVersion: trunk
+      implicit: true
+      allowVersionOverride: false
+      includeInChangesets: false
+`)
+
+		library, err := libraryClient.Get("legacy")
+		Expect(err).To(BeNil())
+		library.DefaultVersion = "trunk"
+		library.Implicit = true
+		err = libraryClient.Update(*library)
+		Expect(err).To(BeNil())
+	})
+
+	It("switch the SCM source", func() {
+		response := casc.PrepareForSASCExport(roundTripper, libraryClient.URL, "", "")
+		response.Body = ioutil.NopCloser(bytes.NewBufferString(sampleLibrariesConfig))
+		casc.PrepareForSASCApplyConfig(roundTripper, libraryClient.URL, "", "", `unclassified:
+  globalLibraries:
+    libraries:
+    - defaultVersion: master
+      name: common
+      implicit: true
+      retriever:
+        modernSCM:
+          scm:
+            github:
+              repoOwner: fake
+              repository: common
+      cachingConfiguration:
+        refreshTimeMinutes: 60
+      allowVersionOverride: false
+      includeInChangesets: false
+    - name: tools
+      retriever:
+        modernSCM:
+          scm:
+            github:
+              repoOwner: fake
+              repository: tools
+`)
+
+		err := libraryClient.Update(SharedLibrary{
+			Name:           "common",
+			DefaultVersion: "master",
+			Implicit:       true,
+			Retriever: LibraryRetriever{ModernSCM: &ModernSCMRetriever{
+				SCM: LibrarySCM{GitHub: &GitHubSCMSource{RepoOwner: "fake", Repository: "common"}},
+			}},
+		})
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("build libraries test", func() {
+	var (
+		ctrl         *gomock.Controller
+		jobClient    Client
+		roundTripper *mhttp.MockRoundTripper
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		jobClient = Client{}
+		roundTripper = mhttp.NewMockRoundTripper(ctrl)
+		jobClient.RoundTripper = roundTripper
+		jobClient.URL = "http://localhost"
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("GetBuildLibraries", func() {
+		request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/job/fake/1/api/json", jobClient.URL), nil)
+		response := &http.Response{
+			StatusCode: 200,
+			Request:    request,
+			Body: ioutil.NopCloser(bytes.NewBufferString(`{"actions":[{},{
+"_class":"org.jenkinsci.plugins.workflow.libs.LibrariesAction",
+"libraries":[{"name":"common","version":"master","implicit":true,"trusted":true}]}]}`)),
+		}
+		roundTripper.EXPECT().
+			RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+
+		libraries, err := jobClient.GetBuildLibraries("fake", BuildNumber(1))
+		Expect(err).To(BeNil())
+		Expect(libraries).To(HaveLen(1))
+		Expect(libraries[0].Name).To(Equal("common"))
+		Expect(libraries[0].Version).To(Equal("master"))
+		Expect(libraries[0].Trusted).To(BeTrue())
+	})
+
+	It("GetBuildLibraries of the last build", func() {
+		request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/job/fake/lastBuild/api/json", jobClient.URL), nil)
+		response := &http.Response{
+			StatusCode: 200,
+			Request:    request,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"actions":[]}`)),
+		}
+		roundTripper.EXPECT().
+			RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+
+		libraries, err := jobClient.GetBuildLibraries("fake", BuildRefFromID(-1))
+		Expect(err).To(BeNil())
+		Expect(libraries).To(BeEmpty())
+	})
+})
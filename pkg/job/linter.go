@@ -0,0 +1,175 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	httpdownloader "github.com/linuxsuren/http-downloader/pkg"
+)
+
+// PipelineLinter validates and converts the declarative Pipeline via pipeline-model-converter
+type PipelineLinter struct {
+	core.JenkinsCore
+}
+
+// LintError represents an error of a declarative Pipeline
+type LintError struct {
+	Message  string
+	Line     int
+	Column   int
+	Location []string
+}
+
+// LintErrors is a set of errors of a declarative Pipeline
+type LintErrors []LintError
+
+// Error returns the messages of all errors
+func (e LintErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, item := range e {
+		messages[i] = item.Message
+	}
+	return strings.Join(messages, "\n")
+}
+
+// LintResult is the validation result of a declarative Pipeline
+type LintResult struct {
+	Valid  bool
+	Errors LintErrors
+}
+
+type converterResponse struct {
+	// raw is the response body, it's used when the errors cannot be parsed
+	raw    []byte
+	Status string
+	Data   struct {
+		Result      string
+		Errors      []converterError
+		JSON        json.RawMessage `json:"json"`
+		Jenkinsfile string
+	}
+}
+
+type converterError struct {
+	Error    json.RawMessage
+	Location []string
+}
+
+var lintPositionReg = regexp.MustCompile(`@ line (\d+), column (\d+)`)
+
+// Validate validates a Jenkinsfile, returns the errors with line and column
+func (l *PipelineLinter) Validate(jenkinsfile string) (result *LintResult, err error) {
+	var data *converterResponse
+	if data, err = l.convert("/pipeline-model-converter/validateJenkinsfile",
+		url.Values{"jenkinsfile": {jenkinsfile}}); err == nil {
+		result = data.lintResult()
+	}
+	return
+}
+
+// ValidateJSON validates the JSON model of a declarative Pipeline
+func (l *PipelineLinter) ValidateJSON(model json.RawMessage) (result *LintResult, err error) {
+	var data *converterResponse
+	if data, err = l.convert("/pipeline-model-converter/validateJson",
+		url.Values{"json": {string(model)}}); err == nil {
+		result = data.lintResult()
+	}
+	return
+}
+
+// ToJSON converts a Jenkinsfile to its JSON model, the error is LintErrors if the Jenkinsfile is invalid
+func (l *PipelineLinter) ToJSON(jenkinsfile string) (model json.RawMessage, err error) {
+	var data *converterResponse
+	if data, err = l.convert("/pipeline-model-converter/toJson",
+		url.Values{"jenkinsfile": {jenkinsfile}}); err == nil {
+		if result := data.lintResult(); result.Valid {
+			model = data.Data.JSON
+		} else {
+			err = data.conversionError(result)
+		}
+	}
+	return
+}
+
+// ToJenkinsfile converts a JSON model to Jenkinsfile, the error is LintErrors if the model is invalid
+func (l *PipelineLinter) ToJenkinsfile(model json.RawMessage) (jenkinsfile string, err error) {
+	var data *converterResponse
+	if data, err = l.convert("/pipeline-model-converter/toJenkinsfile",
+		url.Values{"json": {string(model)}}); err == nil {
+		if result := data.lintResult(); result.Valid {
+			jenkinsfile = data.Data.Jenkinsfile
+		} else {
+			err = data.conversionError(result)
+		}
+	}
+	return
+}
+
+func (l *PipelineLinter) convert(api string, formData url.Values) (data *converterResponse, err error) {
+	payload := strings.NewReader(formData.Encode())
+	var (
+		statusCode int
+		raw        []byte
+	)
+	if statusCode, raw, err = l.Request(http.MethodPost, api,
+		map[string]string{httpdownloader.ContentType: httpdownloader.ApplicationForm}, payload); err != nil {
+		return
+	}
+	if statusCode != http.StatusOK {
+		err = l.ErrorHandle(statusCode, raw)
+		return
+	}
+
+	data = &converterResponse{raw: raw}
+	if err = json.Unmarshal(raw, data); err == nil && data.Status != "ok" {
+		err = fmt.Errorf("unexpected status of pipeline-model-converter: %s", data.Status)
+	}
+	return
+}
+
+// conversionError returns the errors of a failed conversion, the raw response is used if there's no parsed error
+func (r *converterResponse) conversionError(result *LintResult) error {
+	if len(result.Errors) == 0 {
+		return fmt.Errorf("failed to convert the Pipeline, response: %s", string(r.raw))
+	}
+	return result.Errors
+}
+
+func (r *converterResponse) lintResult() (result *LintResult) {
+	result = &LintResult{Valid: r.Data.Result == "success"}
+	for _, item := range r.Data.Errors {
+		for _, message := range item.messages() {
+			result.Errors = append(result.Errors, newLintError(message, item.Location))
+		}
+	}
+	return
+}
+
+// messages returns the error messages, the error could be a string or an array of string
+func (e converterError) messages() (messages []string) {
+	var message string
+	if err := json.Unmarshal(e.Error, &message); err == nil {
+		messages = []string{message}
+	} else {
+		_ = json.Unmarshal(e.Error, &messages)
+	}
+	return
+}
+
+func newLintError(message string, location []string) (lintError LintError) {
+	lintError = LintError{
+		Message:  message,
+		Location: location,
+	}
+	if matches := lintPositionReg.FindStringSubmatch(message); len(matches) == 3 {
+		lintError.Line, _ = strconv.Atoi(matches[1])
+		lintError.Column, _ = strconv.Atoi(matches[2])
+	}
+	return
+}
@@ -0,0 +1,134 @@
+package job
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
+	httpdownloader "github.com/linuxsuren/http-downloader/pkg"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline linter test", func() {
+	var (
+		ctrl         *gomock.Controller
+		linter       PipelineLinter
+		roundTripper *mhttp.MockRoundTripper
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		linter = PipelineLinter{}
+		roundTripper = mhttp.NewMockRoundTripper(ctrl)
+		linter.RoundTripper = roundTripper
+		linter.URL = "http://localhost"
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	given := func(api string, formData url.Values, responseBody string) {
+		request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/pipeline-model-converter/%s", linter.URL, api),
+			strings.NewReader(formData.Encode()))
+		request.Header.Add(httpdownloader.ContentType, httpdownloader.ApplicationForm)
+		core.PrepareCommonPost(request, responseBody, roundTripper, "", "", linter.URL)
+	}
+
+	Context("Validate", func() {
+		It("a valid Jenkinsfile", func() {
+			given("validateJenkinsfile", url.Values{"jenkinsfile": {"pipeline {}"}},
+				`{"status":"ok","data":{"result":"success"}}`)
+
+			result, err := linter.Validate("pipeline {}")
+			Expect(err).To(BeNil())
+			Expect(result.Valid).To(BeTrue())
+			Expect(result.Errors).To(BeEmpty())
+		})
+
+		It("an invalid Jenkinsfile", func() {
+			given("validateJenkinsfile", url.Values{"jenkinsfile": {"pipeline {"}},
+				`{"status":"ok","data":{"result":"failure","errors":[{"error":[
+"WorkflowScript: 2: Missing required section \"stages\" @ line 2, column 5.",
+"WorkflowScript: 1: Missing required section \"agent\" @ line 1, column 1."]}]}}`)
+
+			result, err := linter.Validate("pipeline {")
+			Expect(err).To(BeNil())
+			Expect(result.Valid).To(BeFalse())
+			Expect(result.Errors).To(HaveLen(2))
+			Expect(result.Errors[0].Line).To(Equal(2))
+			Expect(result.Errors[0].Column).To(Equal(5))
+			Expect(result.Errors[1].Line).To(Equal(1))
+		})
+
+		It("unexpected status", func() {
+			given("validateJenkinsfile", url.Values{"jenkinsfile": {"pipeline {}"}}, `{"status":"error"}`)
+
+			_, err := linter.Validate("pipeline {}")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ValidateJSON", func() {
+		It("an invalid model", func() {
+			given("validateJson", url.Values{"json": {`{"pipeline":{}}`}},
+				`{"status":"ok","data":{"result":"failure","errors":[{"location":["pipeline"],"error":"Missing required section 'stages'"}]}}`)
+
+			result, err := linter.ValidateJSON([]byte(`{"pipeline":{}}`))
+			Expect(err).To(BeNil())
+			Expect(result.Valid).To(BeFalse())
+			Expect(result.Errors).To(HaveLen(1))
+			Expect(result.Errors[0].Location).To(Equal([]string{"pipeline"}))
+		})
+	})
+
+	Context("ToJSON", func() {
+		It("should success", func() {
+			given("toJson", url.Values{"jenkinsfile": {"pipeline {}"}},
+				`{"status":"ok","data":{"result":"success","json":{"pipeline":{"stages":[]}}}}`)
+
+			model, err := linter.ToJSON("pipeline {}")
+			Expect(err).To(BeNil())
+			Expect(string(model)).To(Equal(`{"pipeline":{"stages":[]}}`))
+		})
+
+		It("with an invalid Jenkinsfile", func() {
+			given("toJson", url.Values{"jenkinsfile": {"pipeline {"}},
+				`{"status":"ok","data":{"result":"failure","errors":[{"error":"unexpected char @ line 1, column 10."}]}}`)
+
+			_, err := linter.ToJSON("pipeline {")
+			Expect(err).To(HaveOccurred())
+			lintErrors, ok := err.(LintErrors)
+			Expect(ok).To(BeTrue())
+			Expect(lintErrors[0].Column).To(Equal(10))
+			Expect(err.Error()).To(Equal("unexpected char @ line 1, column 10."))
+		})
+	})
+
+	Context("ToJenkinsfile", func() {
+		It("should success", func() {
+			given("toJenkinsfile", url.Values{"json": {`{"pipeline":{}}`}},
+				`{"status":"ok","data":{"result":"success","jenkinsfile":"pipeline {}"}}`)
+
+			jenkinsfile, err := linter.ToJenkinsfile([]byte(`{"pipeline":{}}`))
+			Expect(err).To(BeNil())
+			Expect(jenkinsfile).To(Equal("pipeline {}"))
+		})
+
+		It("with errors which cannot be parsed", func() {
+			response := `{"status":"ok","data":{"result":"failure","errors":[{"error":{"unknown":true}}]}}`
+			given("toJenkinsfile", url.Values{"json": {`{"pipeline":{}}`}}, response)
+
+			_, err := linter.ToJenkinsfile([]byte(`{"pipeline":{}}`))
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(LintErrors)
+			Expect(ok).To(BeFalse())
+			Expect(err.Error()).To(ContainSubstring(response))
+		})
+	})
+})
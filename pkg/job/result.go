@@ -0,0 +1,163 @@
+package job
+
+import "strings"
+
+// BuildResult is the result of a build
+type BuildResult string
+
+const (
+	// BuildResultSuccess means the build had no errors
+	BuildResultSuccess BuildResult = "SUCCESS"
+	// BuildResultUnstable means the build had some errors but they were not fatal
+	BuildResultUnstable BuildResult = "UNSTABLE"
+	// BuildResultFailure means the build had a fatal error
+	BuildResultFailure BuildResult = "FAILURE"
+	// BuildResultNotBuilt means the module was not built
+	BuildResultNotBuilt BuildResult = "NOT_BUILT"
+	// BuildResultAborted means the build was manually aborted
+	BuildResultAborted BuildResult = "ABORTED"
+)
+
+// resultOrdinals follows the order of hudson.model.Result, the smaller is the better
+var resultOrdinals = map[BuildResult]int{
+	BuildResultSuccess:  0,
+	BuildResultUnstable: 1,
+	BuildResultFailure:  2,
+	BuildResultNotBuilt: 3,
+	BuildResultAborted:  4,
+}
+
+// IsCompleted returns true if the result is known, the result is empty while the build is running
+func (r BuildResult) IsCompleted() bool {
+	_, ok := resultOrdinals[r]
+	return ok
+}
+
+// IsBetterOrEqualTo returns true if the result is better or equal to the other one
+func (r BuildResult) IsBetterOrEqualTo(other BuildResult) bool {
+	ordinal, ok := resultOrdinals[r]
+	otherOrdinal, otherOK := resultOrdinals[other]
+	return ok && otherOK && ordinal <= otherOrdinal
+}
+
+// BallColor is the color of the status ball of a job, e.g. blue, red_anime
+type BallColor string
+
+const (
+	// BallColorBlue means the last build was successful
+	BallColorBlue BallColor = "blue"
+	// BallColorYellow means the last build was unstable
+	BallColorYellow BallColor = "yellow"
+	// BallColorRed means the last build was failed
+	BallColorRed BallColor = "red"
+	// BallColorAborted means the last build was aborted
+	BallColorAborted BallColor = "aborted"
+	// BallColorNotBuilt means the last build was not built
+	BallColorNotBuilt BallColor = "notbuilt"
+	// BallColorGrey means there is no build yet
+	BallColorGrey BallColor = "grey"
+	// BallColorDisabled means the job is disabled
+	BallColorDisabled BallColor = "disabled"
+
+	ballColorAnimeSuffix = "_anime"
+)
+
+// IsAnimated returns true if there is a build in progress
+func (c BallColor) IsAnimated() bool {
+	return strings.HasSuffix(string(c), ballColorAnimeSuffix)
+}
+
+// NoAnime returns the color without the in progress suffix
+func (c BallColor) NoAnime() BallColor {
+	return BallColor(strings.TrimSuffix(string(c), ballColorAnimeSuffix))
+}
+
+// Result returns the build result which the color stands for, returns empty if there is no completed build
+func (c BallColor) Result() BuildResult {
+	switch c.NoAnime() {
+	case BallColorBlue:
+		return BuildResultSuccess
+	case BallColorYellow:
+		return BuildResultUnstable
+	case BallColorRed:
+		return BuildResultFailure
+	case BallColorAborted:
+		return BuildResultAborted
+	case BallColorNotBuilt:
+		return BuildResultNotBuilt
+	}
+	return ""
+}
+
+// HealthScore is the health score of a job, the range is from 0 to 100
+type HealthScore int
+
+// Weather returns the description of the weather which stands for the score
+func (s HealthScore) Weather() string {
+	switch {
+	case s <= 20:
+		return "stormy"
+	case s <= 40:
+		return "raining"
+	case s <= 60:
+		return "cloudy"
+	case s <= 80:
+		return "partly cloudy"
+	default:
+		return "sunny"
+	}
+}
+
+// IconClassName returns the icon class name of the score, it is the same with Jenkins
+func (s HealthScore) IconClassName() string {
+	switch {
+	case s <= 20:
+		return "icon-health-00to19"
+	case s <= 40:
+		return "icon-health-20to39"
+	case s <= 60:
+		return "icon-health-40to59"
+	case s <= 80:
+		return "icon-health-60to79"
+	default:
+		return "icon-health-80plus"
+	}
+}
+
+// HealthReport represents a health report of a job
+type HealthReport struct {
+	Description   string
+	IconClassName string
+	IconURL       string `json:"iconUrl"`
+	Score         HealthScore
+}
+
+// IsBuilding returns true if there is a build of the job in progress
+func (j *Job) IsBuilding() bool {
+	return j.Color.IsAnimated()
+}
+
+// IsDisabled returns true if the job is disabled
+func (j *Job) IsDisabled() bool {
+	return j.Color.NoAnime() == BallColorDisabled
+}
+
+// LastResult returns the result of the last completed build
+func (j *Job) LastResult() BuildResult {
+	return j.Color.Result()
+}
+
+// IsStable returns true if the last completed build was successful
+func (j *Job) IsStable() bool {
+	return j.LastResult() == BuildResultSuccess
+}
+
+// Health returns the worst health report of the job as Jenkins does, it's nil if there's no report
+func (j *Job) Health() (report *HealthReport) {
+	for i := range j.HealthReport {
+		if report == nil || j.HealthReport[i].Score < report.Score {
+			report = &j.HealthReport[i]
+		}
+	}
+	return
+}
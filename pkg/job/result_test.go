@@ -0,0 +1,131 @@
+package job
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBallColor(t *testing.T) {
+	tests := []struct {
+		name         string
+		color        BallColor
+		wantAnimated bool
+		wantResult   BuildResult
+	}{{
+		name:       "successful",
+		color:      BallColorBlue,
+		wantResult: BuildResultSuccess,
+	}, {
+		name:         "failed and building",
+		color:        "red_anime",
+		wantAnimated: true,
+		wantResult:   BuildResultFailure,
+	}, {
+		name:       "unstable",
+		color:      BallColorYellow,
+		wantResult: BuildResultUnstable,
+	}, {
+		name:       "aborted",
+		color:      BallColorAborted,
+		wantResult: BuildResultAborted,
+	}, {
+		name:       "not built",
+		color:      BallColorNotBuilt,
+		wantResult: BuildResultNotBuilt,
+	}, {
+		name:         "the first build is running",
+		color:        "notbuilt_anime",
+		wantAnimated: true,
+		wantResult:   BuildResultNotBuilt,
+	}, {
+		name:       "disabled",
+		color:      BallColorDisabled,
+		wantResult: "",
+	}, {
+		name:       "unknown color",
+		color:      "fake",
+		wantResult: "",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.color.IsAnimated(); got != tt.wantAnimated {
+				t.Errorf("IsAnimated() = %v, want %v", got, tt.wantAnimated)
+			}
+			if got := tt.color.Result(); got != tt.wantResult {
+				t.Errorf("Result() = %v, want %v", got, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestBuildResult(t *testing.T) {
+	if BuildResult("").IsCompleted() {
+		t.Errorf("empty result should not be completed")
+	}
+	if !BuildResultAborted.IsCompleted() {
+		t.Errorf("aborted result should be completed")
+	}
+	if !BuildResultSuccess.IsBetterOrEqualTo(BuildResultUnstable) {
+		t.Errorf("success should be better than unstable")
+	}
+	if BuildResultFailure.IsBetterOrEqualTo(BuildResultUnstable) {
+		t.Errorf("failure should be worse than unstable")
+	}
+	if BuildResult("").IsBetterOrEqualTo(BuildResultAborted) {
+		t.Errorf("empty result cannot be compared")
+	}
+}
+
+func TestHealthScore(t *testing.T) {
+	tests := []struct {
+		score         HealthScore
+		wantWeather   string
+		wantIconClass string
+	}{
+		{score: 0, wantWeather: "stormy", wantIconClass: "icon-health-00to19"},
+		{score: 40, wantWeather: "raining", wantIconClass: "icon-health-20to39"},
+		{score: 41, wantWeather: "cloudy", wantIconClass: "icon-health-40to59"},
+		{score: 80, wantWeather: "partly cloudy", wantIconClass: "icon-health-60to79"},
+		{score: 100, wantWeather: "sunny", wantIconClass: "icon-health-80plus"},
+	}
+	for _, tt := range tests {
+		if got := tt.score.Weather(); got != tt.wantWeather {
+			t.Errorf("Weather() of %d = %v, want %v", tt.score, got, tt.wantWeather)
+		}
+		if got := tt.score.IconClassName(); got != tt.wantIconClass {
+			t.Errorf("IconClassName() of %d = %v, want %v", tt.score, got, tt.wantIconClass)
+		}
+	}
+}
+
+func TestJobStatus(t *testing.T) {
+	job := &Job{}
+	if err := json.Unmarshal([]byte(`{
+  "color": "blue_anime",
+  "healthReport": [
+    {"description": "Build stability: No recent builds failed.", "score": 100},
+    {"description": "Test Result: 2 tests failing out of a total of 10 tests.", "score": 80}
+  ]
+}`), job); err != nil {
+		t.Fatalf("failed to unmarshal job: %v", err)
+	}
+
+	if !job.IsBuilding() {
+		t.Errorf("IsBuilding() should be true")
+	}
+	if job.IsDisabled() {
+		t.Errorf("IsDisabled() should be false")
+	}
+	if got := job.LastResult(); got != BuildResultSuccess {
+		t.Errorf("LastResult() = %v, want %v", got, BuildResultSuccess)
+	}
+	if !job.IsStable() {
+		t.Errorf("IsStable() should be true")
+	}
+	if health := job.Health(); health == nil || health.Score != 80 {
+		t.Errorf("Health() = %v, want the report with score 80", health)
+	}
+	if health := (&Job{}).Health(); health != nil {
+		t.Errorf("Health() = %v, want nil", health)
+	}
+}
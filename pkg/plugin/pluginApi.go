@@ -49,9 +49,9 @@ type Info struct {
 	FirstRelease      string            `json:"firstRelease"`
 	Gav               string            `json:"gav"`
 	Name              string            `json:"name"`
-	PreviousTimestamp string            `json:"previousTimestamp"`
+	PreviousTimestamp util.Time         `json:"previousTimestamp"`
 	PreviousVersion   string            `json:"previousVersion"`
-	ReleaseTimestamp  string            `json:"releaseTimestamp"`
+	ReleaseTimestamp  util.Time         `json:"releaseTimestamp"`
 	RequireCore       string            `json:"RequireCore"`
 	Title             string            `json:"title"`
 	URL               string            `json:"url"`
@@ -72,7 +72,7 @@ type InfoStats struct {
 
 // InstallationInfo represents the plugin installation info
 type InstallationInfo struct {
-	Timestamp  int64
+	Timestamp  util.Time
 	Total      int
 	Version    string
 	Percentage float64
@@ -1,6 +1,8 @@
 package plugin
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
 
 	"github.com/golang/mock/gomock"
@@ -43,6 +45,21 @@ var _ = Describe("plugin api test", func() {
 		})
 	})
 
+	Context("GetPlugin", func() {
+		It("parse the timestamps", func() {
+			response := PrepareShowTrend(roundTripper, "fake")
+			response.Body = ioutil.NopCloser(bytes.NewBufferString(`{"name":"fake",
+"releaseTimestamp":"2021-08-23T17:03:04.144Z","previousTimestamp":null,
+"stats":{"installations":[{"timestamp":1629738184144,"total":1512}]}}`))
+
+			plugin, err := pluginAPI.GetPlugin("fake")
+			Expect(err).To(BeNil())
+			Expect(plugin.ReleaseTimestamp.Millis()).To(Equal(int64(1629738184144)))
+			Expect(plugin.PreviousTimestamp.IsZero()).To(BeTrue())
+			Expect(plugin.Stats.Installations[0].Timestamp.Millis()).To(Equal(int64(1629738184144)))
+		})
+	})
+
 	Context("DownloadPlugins", func() {
 		var (
 			names []string
@@ -3,6 +3,7 @@ package plugin
 import (
 	"fmt"
 	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/util"
 	"net/http"
 	"net/url"
 	"strings"
@@ -59,7 +60,7 @@ type InstallationJobStatus struct {
 type CenterSite struct {
 	AvailablesPlugins  []CenterPlugin `json:"availables"`
 	ConnectionCheckURL string         `json:"connectionCheckUrl"`
-	DataTimestamp      int64          `json:"dataTimestamp"`
+	DataTimestamp      util.Time      `json:"dataTimestamp"`
 	HasUpdates         bool           `json:"hasUpdates"`
 	ID                 string         `json:"id"`
 	UpdatePlugins      []CenterPlugin `json:"updates"`
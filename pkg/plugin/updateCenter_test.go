@@ -225,6 +225,7 @@ var _ = Describe("update center test", func() {
 			plugins, err := manager.GetSite()
 			Expect(err).To(BeNil())
 			Expect(plugins.UpdatePlugins[0].Name).To(Equal("blueocean-commons"))
+			Expect(plugins.DataTimestamp.Millis()).To(Equal(int64(1567952107517)))
 		})
 	})
 
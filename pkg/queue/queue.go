@@ -3,9 +3,16 @@ package queue
 import (
 	"fmt"
 	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/job"
+	"github.com/jenkins-zh/jenkins-client/pkg/util"
 	"net/http"
+	"strings"
+	"time"
 )
 
+// parametersActionClass is the class of the action which has the parameters of a queue item
+const parametersActionClass = "hudson.model.ParametersAction"
+
 // Client is the client of queue
 type Client struct {
 	core.JenkinsCore
@@ -29,6 +36,46 @@ func (q *Client) Cancel(id int) (err error) {
 	return
 }
 
+// GetItem returns a queue item by id, the item is still available for a while after it left the queue
+func (q *Client) GetItem(id int) (item *Item, err error) {
+	api := fmt.Sprintf("/queue/item/%d/api/json", id)
+	err = q.RequestWithData(http.MethodGet, api, nil, nil, 200, &item)
+	return
+}
+
+// WaitForBuild blocks until the queue item is started or cancelled, it returns the build reference
+// of the started build, and the item whose task is the job of the build.
+// The interval less than 1 means 5 seconds, the timeout less than 1 means waiting forever.
+func (q *Client) WaitForBuild(id int, interval, timeout time.Duration) (ref job.BuildRef, item *Item, err error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		if item, err = q.GetItem(id); err != nil {
+			return
+		}
+		if item.Cancelled {
+			err = fmt.Errorf("queue item %d was cancelled", id)
+			return
+		}
+		if item.Executable != nil {
+			ref = job.BuildNumber(item.Executable.Number)
+			return
+		}
+
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			err = fmt.Errorf("timeout waiting for queue item %d, %s", id, item.Why)
+			return
+		}
+		time.Sleep(interval)
+	}
+}
+
 // JobQueue represent the job queue
 type JobQueue struct {
 	Items []Item
@@ -44,20 +91,52 @@ type Item struct {
 	Stuck                      bool
 	URL                        string
 	Why                        string
-	BuildableStartMilliseconds int64
-	InQueueSince               int64
-	Actions                    []CauseAction
+	BuildableStartMilliseconds util.Time
+	InQueueSince               util.Time
+	Actions                    job.BuildActions
+	Class                      string `json:"_class"`
+	Cancelled                  bool
+	Executable                 *Executable
+	Task                       Task
 }
 
-// CauseAction is the collection of causes
-type CauseAction struct {
-	Causes []Cause
+// Executable is the build which was started from a queue item
+type Executable struct {
+	Number int
+	URL    string
 }
 
+// Task is the task of a queue item, it's a job in most cases
+type Task struct {
+	Name  string
+	URL   string
+	Class string `json:"_class"`
+}
+
+// CauseAction is an action of a queue item, it has the causes and parameters
+type CauseAction = job.BuildAction
+
 // Cause represent the reason why job is triggered
-type Cause struct {
-	UpstreamURL      string
-	UpstreamProject  string
-	UpstreamBuild    int
-	ShortDescription string
+type Cause = job.Cause
+
+// Causes returns the causes of the item
+func (i *Item) Causes() []Cause {
+	return i.Actions.Causes()
+}
+
+// Parameters returns the parameters of the item. They are parsed from the params only if there's
+// no parameters action, the value which has multiple lines cannot be parsed correctly from the params.
+func (i *Item) Parameters() (parameters []job.ParameterValue) {
+	for _, action := range i.Actions {
+		if action.Class == parametersActionClass || action.Parameters != nil {
+			return i.Actions.Parameters()
+		}
+	}
+
+	for _, line := range strings.Split(i.Params, "\n") {
+		if pair := strings.SplitN(line, "=", 2); len(pair) == 2 {
+			parameters = append(parameters, job.ParameterValue{Name: pair[0], Value: pair[1]})
+		}
+	}
+	return
 }
@@ -1,8 +1,16 @@
 package queue
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/jenkins-zh/jenkins-client/pkg/core"
+	"github.com/jenkins-zh/jenkins-client/pkg/job"
 	"github.com/jenkins-zh/jenkins-client/pkg/mock/mhttp"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -36,6 +44,7 @@ var _ = Describe("queue test", func() {
 			Expect(queue).NotTo(BeNil())
 			Expect(len(queue.Items)).To(Equal(1))
 			Expect(queue.Items[0].ID).To(Equal(62))
+			Expect(queue.Items[0].InQueueSince.Millis()).To(Equal(int64(1567753826770)))
 		})
 	})
 
@@ -47,4 +56,71 @@ var _ = Describe("queue test", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	givenItem := func(id int, body string) {
+		request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/queue/item/%d/api/json", queueClient.URL, id), nil)
+		response := &http.Response{
+			StatusCode: 200,
+			Request:    request,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+		roundTripper.EXPECT().
+			RoundTrip(core.NewRequestMatcher(request)).Return(response, nil)
+	}
+
+	Context("GetItem", func() {
+		It("a left item", func() {
+			givenItem(3, `{"_class":"hudson.model.Queue$LeftItem","id":3,"cancelled":false,
+"executable":{"number":5,"url":"http://localhost/job/fake/5/"},"task":{"name":"fake","url":"http://localhost/job/fake/"}}`)
+
+			item, err := queueClient.GetItem(3)
+			Expect(err).To(BeNil())
+			Expect(item.Executable.Number).To(Equal(5))
+			Expect(item.Task.Name).To(Equal("fake"))
+		})
+	})
+
+	Context("WaitForBuild", func() {
+		It("the build was started", func() {
+			givenItem(3, `{"_class":"hudson.model.Queue$WaitingItem","id":3,"why":"In the quiet period. Expires in 0.1 sec"}`)
+			givenItem(3, `{"_class":"hudson.model.Queue$LeftItem","id":3,"executable":{"number":5},
+"task":{"name":"fake","url":"http://localhost/job/folder/job/fake/"}}`)
+
+			ref, item, err := queueClient.WaitForBuild(3, time.Millisecond, 0)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(job.BuildNumber(5)))
+			Expect(item.Task.URL).To(Equal("http://localhost/job/folder/job/fake/"))
+		})
+
+		It("the item was cancelled", func() {
+			givenItem(3, `{"_class":"hudson.model.Queue$LeftItem","id":3,"cancelled":true}`)
+
+			_, _, err := queueClient.WaitForBuild(3, time.Millisecond, 0)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("timeout", func() {
+			givenItem(3, `{"_class":"hudson.model.Queue$BuildableItem","id":3,"why":"Waiting for next available executor"}`)
+
+			_, _, err := queueClient.WaitForBuild(3, time.Second, time.Millisecond)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Watch", func() {
+		It("report the stuck item", func() {
+			core.PrepareGetQueue(roundTripper, queueClient.URL, "", "")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			events := queueClient.Watch(ctx, WatchOptions{Interval: time.Minute})
+			event := <-events
+			Expect(event.Type).To(Equal(EventAdded))
+			Expect(event.Item.ID).To(Equal(62))
+			event = <-events
+			Expect(event.Type).To(Equal(EventStuck))
+
+			cancel()
+			Eventually(events).Should(BeClosed())
+		})
+	})
 })
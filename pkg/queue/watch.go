@@ -0,0 +1,203 @@
+package queue
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/job"
+)
+
+// EventType is the type of queue events
+type EventType string
+
+const (
+	// EventAdded means an item entered the queue
+	EventAdded EventType = "Added"
+	// EventRemoved means an item left the queue, it was started or cancelled
+	EventRemoved EventType = "Removed"
+	// EventChanged means the state or the reason of an item was changed
+	EventChanged EventType = "Changed"
+	// EventStuck means an item has been waiting longer than the threshold, it's reported once per item
+	EventStuck EventType = "Stuck"
+	// EventError means the queue cannot be fetched, the watcher keeps going
+	EventError EventType = "Error"
+)
+
+// Event is a change of the queue
+type Event struct {
+	Type EventType
+	Item Item
+	// Previous is the item of the last poll, it only exists for the changed event
+	Previous *Item
+	Err      error
+}
+
+// Filter decides if an item should be watched
+type Filter func(item *Item) bool
+
+// FilterByJob only watches the items of a job, the job name has the same format as the job client
+func FilterByJob(jobName string) Filter {
+	jobPath := strings.TrimSuffix(job.ParseJobPath(jobName), "/")
+	return func(item *Item) bool {
+		taskURL, err := url.Parse(item.Task.URL)
+		if err != nil {
+			return false
+		}
+		taskPath := strings.TrimSuffix(taskURL.Path, "/")
+		if !strings.HasSuffix(taskPath, jobPath) {
+			return false
+		}
+		// the prefix could be the context path of Jenkins, but not a parent folder
+		segments := strings.Split(strings.TrimSuffix(taskPath, jobPath), "/")
+		return len(segments) < 2 || segments[len(segments)-2] != "job"
+	}
+}
+
+// FilterByLabel only watches the items which are waiting for the label.
+// The label is parsed from the reason, see also Item.Blockage
+func FilterByLabel(label string) Filter {
+	return func(item *Item) bool {
+		return item.Blockage().Label == label
+	}
+}
+
+// FilterByCause only watches the items which have a matched cause
+func FilterByCause(match func(cause Cause) bool) Filter {
+	return func(item *Item) bool {
+		for _, action := range item.Actions {
+			for _, cause := range action.Causes {
+				if match(cause) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// WatchOptions is the options of watching the queue
+type WatchOptions struct {
+	// Interval is the polling interval, the default value is 5 seconds
+	Interval time.Duration
+	// StuckThreshold is the waiting time of a stuck item, the stuck flag of Jenkins is used if it's zero
+	StuckThreshold time.Duration
+	// Filters are the conditions which need to be all matched
+	Filters []Filter
+}
+
+// Watch polls the queue and sends the changes to the channel until the context is done.
+// The items of the first poll are reported as added.
+func (q *Client) Watch(ctx context.Context, options WatchOptions) <-chan Event {
+	interval := options.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		watcher := &queueWatcher{
+			options: options,
+			items:   map[int]Item{},
+			stuck:   map[int]bool{},
+		}
+		for {
+			queue, err := q.Get()
+			var changes []Event
+			if err != nil {
+				changes = []Event{{Type: EventError, Err: err}}
+			} else {
+				changes = watcher.diff(queue.Items, time.Now())
+			}
+
+			for _, event := range changes {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+	return events
+}
+
+// queueWatcher keeps the items of the last poll
+type queueWatcher struct {
+	options WatchOptions
+	items   map[int]Item
+	stuck   map[int]bool
+}
+
+func (w *queueWatcher) match(item *Item) bool {
+	for _, filter := range w.options.Filters {
+		if !filter(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *queueWatcher) isStuck(item *Item, now time.Time) bool {
+	if w.options.StuckThreshold <= 0 {
+		return item.Stuck
+	}
+	return !item.InQueueSince.IsZero() && now.Sub(item.InQueueSince.Time) > w.options.StuckThreshold
+}
+
+// diff returns the events between the last poll and the current one
+func (w *queueWatcher) diff(items []Item, now time.Time) (events []Event) {
+	current := map[int]Item{}
+	for i := range items {
+		if w.match(&items[i]) {
+			current[items[i].ID] = items[i]
+		}
+	}
+
+	for _, id := range sortedIDs(w.items) {
+		if _, ok := current[id]; !ok {
+			events = append(events, Event{Type: EventRemoved, Item: w.items[id]})
+			delete(w.stuck, id)
+		}
+	}
+	for _, id := range sortedIDs(current) {
+		item := current[id]
+		previous, ok := w.items[id]
+		switch {
+		case !ok:
+			events = append(events, Event{Type: EventAdded, Item: item})
+		case isChanged(&previous, &item):
+			events = append(events, Event{Type: EventChanged, Item: item, Previous: &previous})
+		}
+
+		if !w.stuck[id] && w.isStuck(&item, now) {
+			w.stuck[id] = true
+			events = append(events, Event{Type: EventStuck, Item: item})
+		}
+	}
+	w.items = current
+	return
+}
+
+func isChanged(previous, item *Item) bool {
+	return previous.Class != item.Class || previous.Why != item.Why ||
+		previous.Blocked != item.Blocked || previous.Buildable != item.Buildable ||
+		previous.Pending != item.Pending || previous.Stuck != item.Stuck
+}
+
+func sortedIDs(items map[int]Item) (ids []int) {
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return
+}
@@ -0,0 +1,125 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-zh/jenkins-client/pkg/job"
+	"github.com/jenkins-zh/jenkins-client/pkg/util"
+)
+
+func TestQueueWatcher_diff(t *testing.T) {
+	now := time.Now()
+	watcher := &queueWatcher{
+		options: WatchOptions{StuckThreshold: time.Minute},
+		items:   map[int]Item{},
+		stuck:   map[int]bool{},
+	}
+
+	eventTypes := func(events []Event) (types []EventType) {
+		for _, event := range events {
+			types = append(types, event.Type)
+		}
+		return
+	}
+	assertEvents := func(events []Event, want ...EventType) {
+		t.Helper()
+		got := eventTypes(events)
+		if len(got) != len(want) {
+			t.Fatalf("got events %v, want %v", got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("got events %v, want %v", got, want)
+			}
+		}
+	}
+
+	assertEvents(watcher.diff([]Item{
+		{ID: 1, InQueueSince: util.NewTime(now.Add(-time.Hour))},
+		{ID: 2, InQueueSince: util.NewTime(now), Why: "In the quiet period. Expires in 4.9 sec"},
+	}, now), EventAdded, EventStuck, EventAdded)
+
+	events := watcher.diff([]Item{
+		{ID: 1, InQueueSince: util.NewTime(now.Add(-time.Hour))},
+		{ID: 2, InQueueSince: util.NewTime(now), Why: "Waiting for next available executor"},
+	}, now)
+	assertEvents(events, EventChanged)
+	if events[0].Previous.Why != "In the quiet period. Expires in 4.9 sec" {
+		t.Errorf("unexpected previous item: %+v", events[0].Previous)
+	}
+
+	assertEvents(watcher.diff([]Item{
+		{ID: 2, InQueueSince: util.NewTime(now), Why: "Waiting for next available executor"},
+	}, now.Add(2*time.Minute)), EventRemoved, EventStuck)
+}
+
+func TestFilters(t *testing.T) {
+	item := &Item{
+		Why:  "Waiting for next available executor on ‘linux’",
+		Task: Task{URL: "http://localhost/job/folder/job/fake/"},
+		Actions: job.BuildActions{{}, {
+			Causes: []Cause{{ShortDescription: "Started by timer"}},
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{{
+		name:   "matched job",
+		filter: FilterByJob("folder fake"),
+		want:   true,
+	}, {
+		name:   "a job with the same name in root",
+		filter: FilterByJob("fake"),
+		want:   false,
+	}, {
+		name:   "another job",
+		filter: FilterByJob("other"),
+		want:   false,
+	}, {
+		name:   "matched label",
+		filter: FilterByLabel("linux"),
+		want:   true,
+	}, {
+		name:   "not matched label",
+		filter: FilterByLabel("windows"),
+		want:   false,
+	}, {
+		name: "matched cause",
+		filter: FilterByCause(func(cause Cause) bool {
+			return cause.ShortDescription == "Started by timer"
+		}),
+		want: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter(item); got != tt.want {
+				t.Errorf("filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItem_Parameters(t *testing.T) {
+	item := &Item{Params: "\nenv=prod\nmessage=a=b"}
+	parameters := item.Parameters()
+	if len(parameters) != 2 || parameters[0].Name != "env" || parameters[1].String() != "a=b" {
+		t.Errorf("unexpected parameters: %+v", parameters)
+	}
+
+	item.Actions = job.BuildActions{{
+		Parameters: []job.ParameterValue{{Name: "env", Value: "test"}},
+	}}
+	if parameters = item.Parameters(); len(parameters) != 1 || parameters[0].String() != "test" {
+		t.Errorf("unexpected parameters: %+v", parameters)
+	}
+
+	// the parameters action is the source of truth even if it has no parameters
+	item.Actions = job.BuildActions{{Class: "hudson.model.ParametersAction"}}
+	if parameters = item.Parameters(); len(parameters) != 0 {
+		t.Errorf("unexpected parameters: %+v", parameters)
+	}
+}
@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"regexp"
+	"strings"
+)
+
+const waitingItemClass = "hudson.model.Queue$WaitingItem"
+
+// BlockageType is the type of the reason why a queue item is waiting
+type BlockageType string
+
+const (
+	// BlockageNone means the item is not waiting
+	BlockageNone BlockageType = ""
+	// BlockageQuietPeriod means the item is in the quiet period
+	BlockageQuietPeriod BlockageType = "QuietPeriod"
+	// BlockageExecutor means the item is waiting for an available executor
+	BlockageExecutor BlockageType = "Executor"
+	// BlockageLabel means there's no available node with the label, or all of them are offline
+	BlockageLabel BlockageType = "Label"
+	// BlockageUpstream means an upstream or downstream project is building
+	BlockageUpstream BlockageType = "Upstream"
+	// BlockageConcurrentBuild means the previous build is still running and concurrent builds are disabled
+	BlockageConcurrentBuild BlockageType = "ConcurrentBuild"
+	// BlockageUnknown means the reason cannot be recognized, e.g. in a non-English locale
+	BlockageUnknown BlockageType = "Unknown"
+)
+
+// Blockage is the parsed reason why a queue item is waiting
+type Blockage struct {
+	Type BlockageType
+	// Label is the label or node which the item is waiting for
+	Label string
+	// Project is the upstream or downstream project which blocks the item
+	Project string
+	// Message is the original reason
+	Message string
+}
+
+var (
+	quotedPattern   = regexp.MustCompile(`[‘'"]([^’'"]+)[’'"]`)
+	upstreamPattern = regexp.MustCompile(`(?:Upstream|Downstream) project (.+?) is already (?:building|in progress)`)
+)
+
+// Blockage parses the reason why the item is waiting, it only works with the English messages of Jenkins
+func (i *Item) Blockage() (blockage Blockage) {
+	why := strings.TrimSpace(i.Why)
+	blockage.Message = why
+	switch {
+	case i.Class == waitingItemClass || strings.HasPrefix(why, "In the quiet period"):
+		blockage.Type = BlockageQuietPeriod
+	case why == "":
+		blockage.Type = BlockageNone
+	case upstreamPattern.MatchString(why):
+		blockage.Type = BlockageUpstream
+		blockage.Project = strings.Trim(upstreamPattern.FindStringSubmatch(why)[1], "‘’'\"")
+	case strings.Contains(why, "is already in progress"):
+		blockage.Type = BlockageConcurrentBuild
+	case strings.HasPrefix(why, "Waiting for next available executor on"),
+		strings.HasPrefix(why, "There are no nodes with the label"),
+		strings.HasPrefix(why, "All nodes of label"),
+		strings.HasSuffix(why, "is offline"):
+		blockage.Type = BlockageLabel
+		if matches := quotedPattern.FindStringSubmatch(why); len(matches) > 1 {
+			blockage.Label = matches[1]
+		}
+	case strings.HasPrefix(why, "Waiting for next available executor"):
+		blockage.Type = BlockageExecutor
+	default:
+		blockage.Type = BlockageUnknown
+	}
+	return
+}
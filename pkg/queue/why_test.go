@@ -0,0 +1,50 @@
+package queue
+
+import "testing"
+
+func TestItem_Blockage(t *testing.T) {
+	tests := []struct {
+		name string
+		item Item
+		want Blockage
+	}{{
+		name: "not waiting",
+		item: Item{},
+		want: Blockage{Type: BlockageNone},
+	}, {
+		name: "quiet period",
+		item: Item{Class: waitingItemClass, Why: "In the quiet period. Expires in 4.9 sec"},
+		want: Blockage{Type: BlockageQuietPeriod, Message: "In the quiet period. Expires in 4.9 sec"},
+	}, {
+		name: "waiting for executor",
+		item: Item{Why: "Waiting for next available executor"},
+		want: Blockage{Type: BlockageExecutor, Message: "Waiting for next available executor"},
+	}, {
+		name: "waiting for executor on a label",
+		item: Item{Why: "Waiting for next available executor on ‘linux’"},
+		want: Blockage{Type: BlockageLabel, Label: "linux", Message: "Waiting for next available executor on ‘linux’"},
+	}, {
+		name: "no nodes with the label",
+		item: Item{Why: "There are no nodes with the label ‘windows’"},
+		want: Blockage{Type: BlockageLabel, Label: "windows", Message: "There are no nodes with the label ‘windows’"},
+	}, {
+		name: "upstream project is building",
+		item: Item{Why: "Upstream project ‘parent’ is already building."},
+		want: Blockage{Type: BlockageUpstream, Project: "parent", Message: "Upstream project ‘parent’ is already building."},
+	}, {
+		name: "concurrent build",
+		item: Item{Why: "Build #3 is already in progress (ETA: 1 min 2 sec)"},
+		want: Blockage{Type: BlockageConcurrentBuild, Message: "Build #3 is already in progress (ETA: 1 min 2 sec)"},
+	}, {
+		name: "non-English message",
+		item: Item{Why: "等待下一个可用的执行器"},
+		want: Blockage{Type: BlockageUnknown, Message: "等待下一个可用的执行器"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.Blockage(); got != tt.want {
+				t.Errorf("Blockage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JenkinsTimeLayout is the time layout which is used by BlueOcean and most of the plugins
+const JenkinsTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// timeLayouts are the supported layouts of a timestamp string
+var timeLayouts = []string{
+	JenkinsTimeLayout,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05.000Z0700",
+}
+
+// Time wraps time.Time for more flexible operations.
+// It could be parsed from a string in ISO 8601 or a number of epoch milliseconds,
+// and it's marshaled as a string in RFC 3339 which could be parsed again.
+type Time struct {
+	time.Time
+}
+
+// NewTime returns a Time of t
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// TimeFromMillis returns a Time from epoch milliseconds, zero means an empty time
+func TimeFromMillis(millis int64) Time {
+	if millis == 0 {
+		return Time{}
+	}
+	return NewTime(time.Unix(0, millis*int64(time.Millisecond)).Local())
+}
+
+// IsZero returns the  true if the value is nil or time is zero.
+func (t *Time) IsZero() bool {
+	return t == nil || t.Time.IsZero()
+}
+
+// Millis returns the epoch milliseconds, it's zero if the value is nil or time is zero
+func (t *Time) Millis() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// UnmarshalJSON implements the json Unmarshaler interface.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if len(data) == 4 && string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '"' {
+		var millis int64
+		if err := json.Unmarshal(data, &millis); err != nil {
+			return err
+		}
+		*t = TimeFromMillis(millis)
+		return nil
+	}
+
+	var timeStr string
+	if err := json.Unmarshal(data, &timeStr); err != nil {
+		return err
+	}
+	for _, layout := range timeLayouts {
+		if parsedTime, err := time.Parse(layout, timeStr); err == nil {
+			if !parsedTime.IsZero() {
+				parsedTime = parsedTime.Local()
+			}
+			t.Time = parsedTime
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as a Jenkins time", timeStr)
+}
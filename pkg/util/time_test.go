@@ -0,0 +1,161 @@
+package util
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTime_UnmarshalJSON(t1 *testing.T) {
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    time.Time
+		wantErr bool
+	}{{
+		name: "normal Jenkins time",
+		args: args{
+			data: []byte(`"2021-08-23T17:03:04.144+0000"`),
+		},
+		want: time.Date(2021, 8, 23, 17, 3, 4, 144000000, time.UTC).Local(),
+	}, {
+		name: "ISO 8601 time in UTC",
+		args: args{
+			data: []byte(`"2021-08-23T17:03:04Z"`),
+		},
+		want: time.Date(2021, 8, 23, 17, 3, 4, 0, time.UTC).Local(),
+	}, {
+		name: "epoch milliseconds",
+		args: args{
+			data: []byte(`1629738184144`),
+		},
+		want: time.Date(2021, 8, 23, 17, 3, 4, 144000000, time.UTC).Local(),
+	}, {
+		name: "zero epoch milliseconds",
+		args: args{
+			data: []byte(`0`),
+		},
+		want: time.Time{},
+	}, {
+		name: "null time",
+		args: args{
+			data: []byte(`null`),
+		},
+		want: time.Time{},
+	}, {
+		name: "invalid Jenkins time",
+		args: args{
+			data: []byte(`"2021-08-23"`),
+		},
+		wantErr: true,
+	}, {
+		name: "invalid JSON",
+		args: args{
+			data: []byte(`invalid`),
+		},
+		wantErr: true,
+	},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t *testing.T) {
+			got := &Time{}
+			if err := got.UnmarshalJSON(tt.args.data); (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got.Time, tt.want) {
+				t.Errorf("UmarshalJSON() got = %v, want = %v", got.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestTime_IsZero(t1 *testing.T) {
+	tests := []struct {
+		name string
+		time *Time
+		want bool
+	}{{
+		name: "nil time",
+		time: nil,
+		want: true,
+	}, {
+		name: "zero time",
+		time: &Time{Time: time.Time{}},
+		want: true,
+	}, {
+		name: "normal time",
+		time: &Time{Time: time.Now()},
+		want: false,
+	},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			if got := tt.time.IsZero(); got != tt.want {
+				t1.Errorf("IsZero() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTime_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{{
+		name: "normal Jenkins time",
+		data: `{"time":"2021-08-23T17:03:04.144+0000"}`,
+	}, {
+		name: "ISO 8601 time in UTC",
+		data: `{"time":"2021-08-23T17:03:04Z"}`,
+	}, {
+		name: "epoch milliseconds",
+		data: `{"time":1629738184144}`,
+	}, {
+		name: "zero epoch milliseconds",
+		data: `{"time":0}`,
+	}, {
+		name: "null time",
+		data: `{"time":null}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := struct {
+				Time Time `json:"time"`
+			}{}
+			if err := json.Unmarshal([]byte(tt.data), &value); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			got := struct {
+				Time Time `json:"time"`
+			}{}
+			if err = json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !got.Time.Equal(value.Time.Time) {
+				t.Errorf("json.Marshal() got = %s, want the same time as %s", data, tt.data)
+			}
+			if got.Time != value.Time {
+				t.Errorf("json.Marshal() got = %s, want the comparable value of %s", data, tt.data)
+			}
+		})
+	}
+}
+
+func TestTimeFromMillis(t *testing.T) {
+	value := TimeFromMillis(1629738184144)
+	if got := value.Millis(); got != 1629738184144 {
+		t.Errorf("Millis() = %d, want %d", got, 1629738184144)
+	}
+	if got := TimeFromMillis(0); !got.IsZero() {
+		t.Errorf("IsZero() = false, want true")
+	}
+}